@@ -0,0 +1,118 @@
+// Package agent runs OpenAI tool-calling loops backed by Gomind memory.
+//
+// RunChatCompletions and RunResponses send the conversation to the
+// model with the Gomind tools attached, execute every tool call the
+// model makes, feed the results back and repeat until the model answers
+// without calling a tool or the iteration limit is reached.
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	gomind "github.com/ingate/gomind-go-sdk"
+)
+
+// DefaultMaxIterations is the number of model calls made when
+// Options.MaxIterations is zero.
+const DefaultMaxIterations = 10
+
+// ErrMaxIterations is returned, together with the partial result, when
+// the model is still calling tools after Options.MaxIterations turns.
+var ErrMaxIterations = errors.New("agent: max iterations reached")
+
+// ResultFormat selects how tool results are sent back to the model.
+type ResultFormat int
+
+const (
+	// FormatText renders results with Client.HandleToolCallText: TOON
+	// for recalls, short confirmations for writes.
+	FormatText ResultFormat = iota
+	// FormatJSON sends the raw JSON result, as HandleToolCallJSON does.
+	FormatJSON
+)
+
+// Options configures an agent run.
+type Options struct {
+	// Model is the OpenAI model ID. Required.
+	Model string
+
+	// MaxIterations caps the number of model calls. Zero uses
+	// DefaultMaxIterations.
+	MaxIterations int
+
+	// Registry supplies the tool definitions and dispatch. When nil a
+	// registry with the built-in Gomind tools is created from the
+	// Gomind client, so its ToolPolicy still applies.
+	Registry *gomind.ToolRegistry
+
+	// InjectSystemPrompt fetches the Gomind system prompt and adds it to
+	// the conversation (as a leading system message for Chat
+	// Completions, as instructions for the Responses API).
+	InjectSystemPrompt bool
+
+	// ResultFormat selects how tool results are rendered.
+	ResultFormat ResultFormat
+
+	// TextOptions controls FormatText rendering.
+	TextOptions gomind.ToolTextOptions
+
+	// Concurrency caps the number of tool calls of one turn executed at
+	// once. Zero uses gomind.DefaultToolConcurrency.
+	Concurrency int
+}
+
+// runner holds the state shared by both API variants.
+type runner struct {
+	client   *gomind.Client
+	registry *gomind.ToolRegistry
+	opts     Options
+}
+
+func newRunner(gc *gomind.Client, opts Options) (*runner, error) {
+	if gc == nil {
+		return nil, fmt.Errorf("gomind client is required")
+	}
+	if opts.Model == "" {
+		return nil, fmt.Errorf("model is required")
+	}
+	if opts.MaxIterations <= 0 {
+		opts.MaxIterations = DefaultMaxIterations
+	}
+	registry := opts.Registry
+	if registry == nil {
+		registry = gomind.NewToolRegistry(gc)
+	}
+	return &runner{client: gc, registry: registry, opts: opts}, nil
+}
+
+// systemPrompt returns the Gomind system prompt, or "" when injection is
+// disabled.
+func (r *runner) systemPrompt(ctx context.Context) (string, error) {
+	if !r.opts.InjectSystemPrompt {
+		return "", nil
+	}
+	resp, err := r.client.SystemPrompt(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch system prompt: %w", err)
+	}
+	return resp.Prompt, nil
+}
+
+// execute runs all tool calls of one model turn as a batch (see
+// gomind.ToolRegistry.HandleCalls) and returns their rendered outputs in
+// call order. Tool errors are rendered into the output so the model can
+// react to them.
+func (r *runner) execute(ctx context.Context, calls []gomind.ToolCall) []string {
+	results := r.registry.HandleCalls(ctx, calls, gomind.ToolBatchOptions{Concurrency: r.opts.Concurrency})
+	outputs := make([]string, len(results))
+	for i, res := range results {
+		if r.opts.ResultFormat == FormatText {
+			outputs[i] = res.Text(r.opts.TextOptions)
+		} else {
+			outputs[i] = res.JSON()
+		}
+	}
+	return outputs
+}
@@ -0,0 +1,174 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/openai/openai-go/v3"
+	"github.com/openai/openai-go/v3/option"
+	"github.com/openai/openai-go/v3/responses"
+
+	gomind "github.com/ingate/gomind-go-sdk"
+)
+
+// newGomindStub serves recall and system-prompt requests and records the
+// recall queries it received.
+func newGomindStub(t *testing.T) (*gomind.Client, *[]string) {
+	t.Helper()
+	var mu sync.Mutex
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/system-prompt":
+			_, _ = w.Write([]byte(`{"status":"OK","result":{"prompt":"You have memory."}}`))
+		case "/v1/recall":
+			var req gomind.RecallRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			mu.Lock()
+			queries = append(queries, req.Query)
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{"status":"OK","result":{"facts":[{"subject":"` + req.Query + `","predicate":"works_at","object":"Acme"}],"count":1}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	gc, err := gomind.NewClient("test-key", gomind.WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return gc, &queries
+}
+
+// newOpenAIStub replays the given response bodies in order and records
+// every request body.
+func newOpenAIStub(t *testing.T, path string, replies ...string) (*openai.Client, *[]string) {
+	t.Helper()
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			t.Errorf("unexpected OpenAI path %q", r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.Header().Set("Content-Type", "application/json")
+		reply := replies[len(replies)-1]
+		if len(bodies) <= len(replies) {
+			reply = replies[len(bodies)-1]
+		}
+		_, _ = w.Write([]byte(reply))
+	}))
+	t.Cleanup(srv.Close)
+
+	oc := openai.NewClient(option.WithBaseURL(srv.URL), option.WithAPIKey("test"), option.WithMaxRetries(0))
+	return &oc, &bodies
+}
+
+const chatToolCalls = `{"id":"c1","object":"chat.completion","model":"gpt-test","choices":[{"index":0,"finish_reason":"tool_calls","message":{"role":"assistant","content":"","tool_calls":[
+	{"id":"call_1","type":"function","function":{"name":"recall","arguments":"{\"query\":\"John\"}"}},
+	{"id":"call_2","type":"function","function":{"name":"recall","arguments":"{\"query\":\"Jane\"}"}},
+	{"id":"call_3","type":"function","function":{"name":"forget_entity","arguments":"{\"entity\":\"John\"}"}}]}}]}`
+
+const chatFinal = `{"id":"c2","object":"chat.completion","model":"gpt-test","choices":[{"index":0,"finish_reason":"stop","message":{"role":"assistant","content":"John and Jane work at Acme."}}]}`
+
+func TestRunChatCompletions(t *testing.T) {
+	gc, queries := newGomindStub(t)
+	oc, bodies := newOpenAIStub(t, "/chat/completions", chatToolCalls, chatFinal)
+
+	reg := gomind.NewToolRegistry(gc)
+	if err := reg.Disable("forget_entity"); err != nil {
+		t.Fatalf("Disable: %v", err)
+	}
+
+	result, err := RunChatCompletions(context.Background(), oc, gc,
+		[]openai.ChatCompletionMessageParamUnion{openai.UserMessage("Where do John and Jane work?")},
+		Options{Model: "gpt-test", Registry: reg, InjectSystemPrompt: true})
+	if err != nil {
+		t.Fatalf("RunChatCompletions: %v", err)
+	}
+
+	if result.Content != "John and Jane work at Acme." || result.Iterations != 2 {
+		t.Errorf("unexpected result: content=%q iterations=%d", result.Content, result.Iterations)
+	}
+	// system + user + assistant(tool calls) + 3 tool results + final assistant
+	if len(result.Messages) != 7 {
+		t.Errorf("expected 7 transcript messages, got %d", len(result.Messages))
+	}
+	if len(*queries) != 2 {
+		t.Errorf("expected both recalls to run, got %v", *queries)
+	}
+
+	if len(*bodies) != 2 {
+		t.Fatalf("expected 2 model calls, got %d", len(*bodies))
+	}
+	first, second := (*bodies)[0], (*bodies)[1]
+	if !strings.Contains(first, "You have memory.") {
+		t.Errorf("expected system prompt in first request, got %s", first)
+	}
+	if strings.Contains(first, `"name":"forget_entity"`) {
+		t.Errorf("disabled tool must not be offered to the model")
+	}
+	for _, want := range []string{`"tool_call_id":"call_1"`, `"tool_call_id":"call_2"`, "John,works_at,Acme", "Error: unknown tool forget_entity"} {
+		if !strings.Contains(second, want) {
+			t.Errorf("expected %q in second request, got %s", want, second)
+		}
+	}
+}
+
+func TestRunChatCompletionsMaxIterations(t *testing.T) {
+	gc, _ := newGomindStub(t)
+	oc, _ := newOpenAIStub(t, "/chat/completions", chatToolCalls)
+
+	result, err := RunChatCompletions(context.Background(), oc, gc,
+		[]openai.ChatCompletionMessageParamUnion{openai.UserMessage("loop")},
+		Options{Model: "gpt-test", MaxIterations: 2, ResultFormat: FormatJSON})
+	if !errors.Is(err, ErrMaxIterations) {
+		t.Fatalf("expected ErrMaxIterations, got %v", err)
+	}
+	if result == nil || result.Iterations != 2 {
+		t.Errorf("expected partial result after 2 iterations, got %+v", result)
+	}
+}
+
+const responsesToolCalls = `{"id":"resp_1","object":"response","model":"gpt-test","status":"completed","output":[
+	{"type":"function_call","id":"fc_1","call_id":"call_1","name":"recall","arguments":"{\"query\":\"John\"}","status":"completed"}]}`
+
+const responsesFinal = `{"id":"resp_2","object":"response","model":"gpt-test","status":"completed","output":[
+	{"type":"message","id":"msg_1","role":"assistant","status":"completed","content":[{"type":"output_text","text":"John works at Acme.","annotations":[]}]}]}`
+
+func TestRunResponses(t *testing.T) {
+	gc, _ := newGomindStub(t)
+	oc, bodies := newOpenAIStub(t, "/responses", responsesToolCalls, responsesFinal)
+
+	input := responses.ResponseInputParam{
+		responses.ResponseInputItemParamOfMessage("Where does John work?", responses.EasyInputMessageRoleUser),
+	}
+	result, err := RunResponses(context.Background(), oc, gc, input,
+		Options{Model: "gpt-test", InjectSystemPrompt: true})
+	if err != nil {
+		t.Fatalf("RunResponses: %v", err)
+	}
+
+	if result.OutputText != "John works at Acme." || result.Iterations != 2 {
+		t.Errorf("unexpected result: output=%q iterations=%d", result.OutputText, result.Iterations)
+	}
+	if len(result.Input) != 2 {
+		t.Errorf("expected user input plus one function output, got %d items", len(result.Input))
+	}
+
+	second := (*bodies)[1]
+	for _, want := range []string{`"previous_response_id":"resp_1"`, `"call_id":"call_1"`, `"instructions":"You have memory."`} {
+		if !strings.Contains(second, want) {
+			t.Errorf("expected %q in second request, got %s", want, second)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openai/openai-go/v3"
+
+	gomind "github.com/ingate/gomind-go-sdk"
+	"github.com/ingate/gomind-go-sdk/tools"
+)
+
+// ChatResult is the outcome of RunChatCompletions.
+type ChatResult struct {
+	// Messages is the full transcript: the input messages (plus the
+	// injected system prompt), every assistant message and every tool
+	// result message.
+	Messages []openai.ChatCompletionMessageParamUnion
+
+	// Completions holds the raw response of every model call.
+	Completions []*openai.ChatCompletion
+
+	// Content is the final assistant answer.
+	Content string
+
+	// Iterations is the number of model calls made.
+	Iterations int
+}
+
+// RunChatCompletions runs a Chat Completions tool-calling loop. It
+// returns when the model replies without tool calls; if
+// Options.MaxIterations is reached first it returns the partial result
+// together with ErrMaxIterations.
+func RunChatCompletions(ctx context.Context, oc *openai.Client, gc *gomind.Client, messages []openai.ChatCompletionMessageParamUnion, opts Options) (*ChatResult, error) {
+	if oc == nil {
+		return nil, fmt.Errorf("openai client is required")
+	}
+	r, err := newRunner(gc, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	prompt, err := r.systemPrompt(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ChatResult{}
+	if prompt != "" {
+		result.Messages = append(result.Messages, openai.SystemMessage(prompt))
+	}
+	result.Messages = append(result.Messages, messages...)
+
+	params := openai.ChatCompletionNewParams{
+		Model: r.opts.Model,
+		Tools: tools.ToOpenAI(r.registry.Definitions()),
+	}
+
+	for result.Iterations < r.opts.MaxIterations {
+		params.Messages = result.Messages
+		completion, err := oc.Chat.Completions.New(ctx, params)
+		if err != nil {
+			return result, fmt.Errorf("chat completion failed: %w", err)
+		}
+		result.Iterations++
+		result.Completions = append(result.Completions, completion)
+
+		if len(completion.Choices) == 0 {
+			return result, fmt.Errorf("chat completion returned no choices")
+		}
+		message := completion.Choices[0].Message
+		result.Messages = append(result.Messages, message.ToParam())
+		result.Content = message.Content
+
+		calls := make([]gomind.ToolCall, 0, len(message.ToolCalls))
+		for _, tc := range message.ToolCalls {
+			calls = append(calls, gomind.ToolCall{ID: tc.ID, Name: tc.Function.Name, Arguments: tc.Function.Arguments})
+		}
+		if len(calls) == 0 {
+			return result, nil
+		}
+
+		for i, out := range r.execute(ctx, calls) {
+			result.Messages = append(result.Messages, openai.ToolMessage(out, calls[i].ID))
+		}
+	}
+
+	return result, ErrMaxIterations
+}
@@ -0,0 +1,91 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openai/openai-go/v3"
+	"github.com/openai/openai-go/v3/packages/param"
+	"github.com/openai/openai-go/v3/responses"
+
+	gomind "github.com/ingate/gomind-go-sdk"
+	"github.com/ingate/gomind-go-sdk/tools"
+)
+
+// ResponsesResult is the outcome of RunResponses.
+type ResponsesResult struct {
+	// Input holds every input item sent to the model: the caller's
+	// input followed by the function call outputs of each turn.
+	Input responses.ResponseInputParam
+
+	// Responses holds the raw response of every model call. Together
+	// with Input it forms the full transcript.
+	Responses []*responses.Response
+
+	// OutputText is the final text output of the model.
+	OutputText string
+
+	// Iterations is the number of model calls made.
+	Iterations int
+}
+
+// RunResponses runs a Responses API tool-calling loop. Turns after the
+// first are chained with previous_response_id, so the responses must be
+// stored server-side (the API default). Completion and iteration-limit
+// semantics match RunChatCompletions.
+func RunResponses(ctx context.Context, oc *openai.Client, gc *gomind.Client, input responses.ResponseInputParam, opts Options) (*ResponsesResult, error) {
+	if oc == nil {
+		return nil, fmt.Errorf("openai client is required")
+	}
+	r, err := newRunner(gc, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	prompt, err := r.systemPrompt(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	params := responses.ResponseNewParams{
+		Model: r.opts.Model,
+		Input: responses.ResponseNewParamsInputUnion{OfInputItemList: input},
+		Tools: tools.ToOpenAIResponses(r.registry.Definitions()),
+	}
+	if prompt != "" {
+		params.Instructions = param.NewOpt(prompt)
+	}
+
+	result := &ResponsesResult{Input: append(responses.ResponseInputParam{}, input...)}
+
+	for result.Iterations < r.opts.MaxIterations {
+		resp, err := oc.Responses.New(ctx, params)
+		if err != nil {
+			return result, fmt.Errorf("responses request failed: %w", err)
+		}
+		result.Iterations++
+		result.Responses = append(result.Responses, resp)
+		result.OutputText = resp.OutputText()
+
+		var calls []gomind.ToolCall
+		for _, item := range resp.Output {
+			if item.Type == "function_call" {
+				calls = append(calls, gomind.ToolCall{ID: item.CallID, Name: item.Name, Arguments: item.Arguments})
+			}
+		}
+		if len(calls) == 0 {
+			return result, nil
+		}
+
+		next := make(responses.ResponseInputParam, 0, len(calls))
+		for i, out := range r.execute(ctx, calls) {
+			next = append(next, responses.ResponseInputItemParamOfFunctionCallOutput(calls[i].ID, out))
+		}
+		result.Input = append(result.Input, next...)
+
+		params.Input = responses.ResponseNewParamsInputUnion{OfInputItemList: next}
+		params.PreviousResponseID = param.NewOpt(resp.ID)
+	}
+
+	return result, ErrMaxIterations
+}
@@ -0,0 +1,465 @@
+package gomind
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+)
+
+// Collection archives are newline-delimited JSON: a header record, one
+// record per fact, and an end record carrying the fact count so a
+// truncated archive is detected on import.
+//
+//	{"type":"header","format":"gomind-collection","version":1,"collection":{"code":"crm","name":"CRM"},"exported_at":1767225600}
+//	{"type":"fact","subject":"John","predicate":"works_at","object":"Acme","source":"crm-sync"}
+//	{"type":"end","count":1}
+const (
+	ArchiveFormat  = "gomind-collection"
+	ArchiveVersion = 1
+)
+
+// DefaultImportBatchSize is the number of facts sent per remember_many
+// request when ImportOptions.BatchSize is zero.
+const DefaultImportBatchSize = 100
+
+// ArchiveCollection is the collection metadata stored in an archive
+// header.
+type ArchiveCollection struct {
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// archiveRecord is one line of an archive. Fields are populated
+// according to Type.
+type archiveRecord struct {
+	Type string `json:"type"`
+
+	// header
+	Format     string             `json:"format,omitempty"`
+	Version    int                `json:"version,omitempty"`
+	Collection *ArchiveCollection `json:"collection,omitempty"`
+	ExportedAt int64              `json:"exported_at,omitempty"`
+
+	// fact
+	*Fact
+
+	// end
+	Count int `json:"count,omitempty"`
+}
+
+// ConflictPolicy decides what ImportCollection does with an archived
+// fact that already exists in the target collection. Facts are
+// identified by subject, predicate and object (or value).
+type ConflictPolicy string
+
+const (
+	// ConflictSkip keeps the existing fact. It is the default.
+	ConflictSkip ConflictPolicy = "skip"
+	// ConflictOverwrite forgets the existing fact and stores the
+	// archived one, replacing its context and source.
+	ConflictOverwrite ConflictPolicy = "overwrite"
+	// ConflictFail aborts the import with an *ImportConflictError.
+	ConflictFail ConflictPolicy = "fail"
+)
+
+// ImportOptions configures ImportCollection.
+type ImportOptions struct {
+	// BatchSize is the number of facts per remember_many request.
+	// Defaults to DefaultImportBatchSize.
+	BatchSize int
+
+	// Conflict selects the conflict policy. Defaults to ConflictSkip.
+	Conflict ConflictPolicy
+
+	// Progress, when set, is called after every batch and once at the
+	// end with the running totals.
+	Progress func(ImportSummary)
+}
+
+// ExportSummary reports the result of ExportCollection.
+type ExportSummary struct {
+	Facts int
+}
+
+// ImportSummary reports the progress or result of ImportCollection.
+type ImportSummary struct {
+	// Read counts fact records read from the archive.
+	Read int
+	// Imported counts facts written, including overwritten ones.
+	Imported int
+	// Overwritten counts existing facts replaced under ConflictOverwrite.
+	Overwritten int
+	// Skipped counts facts left alone because they already existed.
+	Skipped int
+}
+
+// ImportConflictError is returned by ImportCollection under
+// ConflictFail when an archived fact already exists.
+type ImportConflictError struct {
+	Fact Fact
+	Line int
+}
+
+func (e *ImportConflictError) Error() string {
+	return fmt.Sprintf("import conflict at line %d: fact %s %s %s already exists",
+		e.Line, e.Fact.Subject, e.Fact.Predicate, getObjectValue(e.Fact))
+}
+
+// factKey identifies a fact for conflict detection. The validity
+// interval is part of the key, so the same triple stored for several
+// periods is several facts.
+type factKey struct {
+	subject   string
+	predicate string
+	object    string
+	validFrom string
+	validTo   string
+}
+
+func keyOf(f Fact) factKey {
+	return factKey{f.Subject, f.Predicate, getObjectValue(f), timeKey(f.ValidFrom), timeKey(f.ValidTo)}
+}
+
+// timeKey renders t for use in a map key; nil is "".
+func timeKey(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// storedFactsLimit caps the facts storedFacts looks up.
+const storedFactsLimit = 100
+
+// storedFacts returns the facts with exactly this subject and predicate
+// in collection, looked up with RecallWithOptions. At most
+// storedFactsLimit facts are considered.
+func (c *Client) storedFacts(ctx context.Context, subject, predicate string, collection *string) ([]Fact, error) {
+	resp, err := c.RecallWithOptions(ctx, RecallRequest{
+		Query:      subject,
+		Predicate:  predicate,
+		Limit:      storedFactsLimit,
+		Collection: collection,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Recall also returns facts about similar subjects.
+	return slices.DeleteFunc(resp.Facts, func(f Fact) bool {
+		return f.Subject != subject || f.Predicate != predicate
+	}), nil
+}
+
+// ExportCollection writes every fact of the collection with the given
+// code to w as a versioned NDJSON archive. Facts are streamed page by
+// page, so memory use does not grow with the collection.
+func (c *Client) ExportCollection(ctx context.Context, orgID, code string, w io.Writer) (*ExportSummary, error) {
+	col, err := c.GetCollectionByCode(ctx, orgID, code)
+	if err != nil {
+		return nil, err
+	}
+
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	err = enc.Encode(archiveRecord{
+		Type:       "header",
+		Format:     ArchiveFormat,
+		Version:    ArchiveVersion,
+		Collection: &ArchiveCollection{Code: col.Code, Name: col.Name, Description: col.Description},
+		ExportedAt: time.Now().Unix(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to write archive header: %w", err)
+	}
+
+	summary := &ExportSummary{}
+	for fact, err := range c.AllFacts(ctx, orgID, code, ListFactsOptions{}) {
+		if err != nil {
+			c.logger.Error("Gomind ExportCollection failed", "error", err, "orgID", orgID, "code", code)
+			return nil, err
+		}
+		if err := enc.Encode(archiveRecord{Type: "fact", Fact: &fact}); err != nil {
+			return nil, fmt.Errorf("failed to write archive fact: %w", err)
+		}
+		summary.Facts++
+	}
+
+	if err := enc.Encode(archiveRecord{Type: "end", Count: summary.Facts}); err != nil {
+		return nil, fmt.Errorf("failed to write archive end: %w", err)
+	}
+	if err := bw.Flush(); err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	c.logger.Info("Gomind ExportCollection success", "orgID", orgID, "code", code, "facts", summary.Facts)
+	return summary, nil
+}
+
+// ImportCollection reads an archive written by ExportCollection and
+// stores its facts in the collection with the given code, creating the
+// collection from the archive header if needed. An empty code imports
+// into the collection named in the header.
+//
+// Facts are streamed and written in batches with remember_many, so
+// memory use is bounded by the batch size. They are stored exactly as
+// archived: the client's Ontology does not rewrite or upsert them. Conflicts are detected per
+// batch by recalling the stored values of each subject and predicate in
+// it, which costs one recall request per distinct pair; subjects with
+// more than 100 values of a predicate may miss conflicts.
+//
+// Batches already written stay written if the import fails part way,
+// including on a ConflictFail conflict; re-running the import with
+// ConflictSkip completes it.
+func (c *Client) ImportCollection(ctx context.Context, orgID, code string, r io.Reader, opts ImportOptions) (*ImportSummary, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultImportBatchSize
+	}
+	if opts.Conflict == "" {
+		opts.Conflict = ConflictSkip
+	}
+
+	imp := &archiveImport{client: c, ctx: ctx, opts: opts}
+	summary, err := imp.run(orgID, code, r)
+	if err != nil {
+		c.logger.Error("Gomind ImportCollection failed", "error", err, "orgID", orgID, "code", code)
+		return summary, err
+	}
+
+	c.logger.Info("Gomind ImportCollection success",
+		"orgID", orgID,
+		"code", imp.code,
+		"imported", summary.Imported,
+		"skipped", summary.Skipped,
+	)
+	return summary, nil
+}
+
+// archiveImport holds the state of one ImportCollection call.
+type archiveImport struct {
+	client *Client
+	ctx    context.Context
+	opts   ImportOptions
+
+	code    string
+	pending []archivedFact
+	source  string
+	summary ImportSummary
+}
+
+// archivedFact is a fact read from an archive and its line number.
+type archivedFact struct {
+	fact Fact
+	line int
+}
+
+func (imp *archiveImport) run(orgID, code string, r io.Reader) (*ImportSummary, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 10<<20)
+
+	line := 0
+	next := func() (*archiveRecord, error) {
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return nil, fmt.Errorf("failed to read archive: %w", err)
+			}
+			return nil, nil
+		}
+		line++
+		var rec archiveRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return nil, fmt.Errorf("failed to parse archive line %d: %w", line, err)
+		}
+		return &rec, nil
+	}
+
+	header, err := next()
+	if err != nil {
+		return &imp.summary, err
+	}
+	if header == nil || header.Type != "header" || header.Format != ArchiveFormat || header.Collection == nil {
+		return &imp.summary, fmt.Errorf("not a %s archive", ArchiveFormat)
+	}
+	if header.Version < 1 || header.Version > ArchiveVersion {
+		return &imp.summary, fmt.Errorf("unsupported archive version %d (supported: %d)", header.Version, ArchiveVersion)
+	}
+
+	imp.code = code
+	if imp.code == "" {
+		imp.code = header.Collection.Code
+	}
+	if _, err := imp.client.EnsureCollection(imp.ctx, orgID, imp.code, header.Collection.Name, header.Collection.Description); err != nil {
+		return &imp.summary, err
+	}
+
+	for {
+		rec, err := next()
+		if err != nil {
+			return &imp.summary, err
+		}
+		if rec == nil {
+			if err := imp.flush(); err != nil {
+				return &imp.summary, err
+			}
+			return &imp.summary, fmt.Errorf("archive ended without an end record: %w", io.ErrUnexpectedEOF)
+		}
+
+		switch rec.Type {
+		case "fact":
+			if rec.Fact == nil || rec.Subject == "" || rec.Predicate == "" {
+				return &imp.summary, fmt.Errorf("invalid fact at archive line %d", line)
+			}
+			if err := imp.add(*rec.Fact, line); err != nil {
+				return &imp.summary, err
+			}
+		case "end":
+			if err := imp.flush(); err != nil {
+				return &imp.summary, err
+			}
+			if rec.Count != imp.summary.Read {
+				return &imp.summary, fmt.Errorf("archive declares %d facts but contains %d", rec.Count, imp.summary.Read)
+			}
+			imp.progress()
+			return &imp.summary, nil
+		}
+		// Unknown record types are skipped for forward compatibility.
+	}
+}
+
+// add queues fact; conflicts are resolved when the batch is flushed.
+func (imp *archiveImport) add(fact Fact, line int) error {
+	imp.summary.Read++
+
+	// A batch carries a single source, so a change of source starts a
+	// new batch.
+	if len(imp.pending) > 0 && fact.Source != imp.source {
+		if err := imp.flush(); err != nil {
+			return err
+		}
+	}
+	imp.source = fact.Source
+	imp.pending = append(imp.pending, archivedFact{fact: fact, line: line})
+	if len(imp.pending) >= imp.opts.BatchSize {
+		return imp.flush()
+	}
+	return nil
+}
+
+// flush applies the conflict policy to the queued facts and writes
+// them. Facts repeated within the batch conflict like stored ones.
+func (imp *archiveImport) flush() error {
+	if len(imp.pending) == 0 {
+		return nil
+	}
+	pending := imp.pending
+	imp.pending = nil
+
+	existing, err := imp.existingKeys(pending)
+	if err != nil {
+		return err
+	}
+	queued := make(map[factKey]int)
+	var batch []RememberRequest
+	for _, p := range pending {
+		key := keyOf(p.fact)
+		req := RememberRequest{
+			Subject:    p.fact.Subject,
+			Predicate:  p.fact.Predicate,
+			Object:     key.object,
+			Context:    p.fact.Context,
+			ValidFrom:  p.fact.ValidFrom,
+			ValidTo:    p.fact.ValidTo,
+			ObservedAt: p.fact.ObservedAt,
+		}
+		i, isQueued := queued[key]
+		stored, isStored := existing[key]
+		if isStored || isQueued {
+			switch imp.opts.Conflict {
+			case ConflictSkip:
+				imp.summary.Skipped++
+				continue
+			case ConflictFail:
+				if err := imp.write(batch); err != nil {
+					return err
+				}
+				return &ImportConflictError{Fact: p.fact, Line: p.line}
+			case ConflictOverwrite:
+				if isQueued {
+					batch[i] = req
+					continue
+				}
+				err := imp.client.ForgetWithOptions(imp.ctx, ForgetRequest{
+					ID:         stored.ID,
+					Subject:    p.fact.Subject,
+					Predicate:  p.fact.Predicate,
+					Object:     key.object,
+					Collection: CollectionScope(imp.code),
+				})
+				if err != nil {
+					return err
+				}
+				imp.summary.Overwritten++
+			default:
+				return fmt.Errorf("unknown conflict policy %q", imp.opts.Conflict)
+			}
+		}
+		queued[key] = len(batch)
+		batch = append(batch, req)
+	}
+	if err := imp.write(batch); err != nil {
+		return err
+	}
+	imp.progress()
+	return nil
+}
+
+// existingKeys returns the pending facts already stored in the target
+// collection, as stored, by key.
+func (imp *archiveImport) existingKeys(pending []archivedFact) (map[factKey]Fact, error) {
+	type pair struct{ subject, predicate string }
+	looked := make(map[pair]bool)
+	existing := make(map[factKey]Fact)
+	for _, p := range pending {
+		k := pair{p.fact.Subject, p.fact.Predicate}
+		if looked[k] {
+			continue
+		}
+		looked[k] = true
+		facts, err := imp.client.storedFacts(imp.ctx, k.subject, k.predicate, CollectionScope(imp.code))
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up existing facts: %w", err)
+		}
+		for _, f := range facts {
+			existing[keyOf(f)] = f
+		}
+	}
+	return existing, nil
+}
+
+// write stores batch in the target collection. Archived facts are
+// restored as they were, bypassing the client's Ontology.
+func (imp *archiveImport) write(batch []RememberRequest) error {
+	if len(batch) == 0 {
+		return nil
+	}
+	err := imp.client.rememberMany(imp.ctx, RememberManyRequest{
+		Facts:      batch,
+		Source:     imp.source,
+		Collection: CollectionScope(imp.code),
+	})
+	if err != nil {
+		return err
+	}
+	imp.summary.Imported += len(batch)
+	return nil
+}
+
+func (imp *archiveImport) progress() {
+	if imp.opts.Progress != nil {
+		imp.opts.Progress(imp.summary)
+	}
+}
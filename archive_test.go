@@ -0,0 +1,444 @@
+package gomind
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeGraph is an in-memory Gomind API for org "org1" covering
+// collections, fact listing, remember_many and forget.
+type fakeGraph struct {
+	mu          sync.Mutex
+	collections []Collection
+	facts       map[string][]Fact // by collection code
+	requests    []string
+	// failWrites makes remember_many fail after that many successful
+	// calls; zero never fails.
+	failWrites int
+	writes     int
+	nextID     int
+	// noListing makes the fact listing endpoint answer 404, like servers
+	// without it.
+	noListing bool
+}
+
+// store appends f to the collection, assigning an ID if it has none.
+// Callers must hold mu.
+func (g *fakeGraph) store(code string, f Fact) {
+	if f.ID == "" {
+		g.nextID++
+		f.ID = fmt.Sprintf("fact-%d", g.nextID)
+	}
+	g.facts[code] = append(g.facts[code], f)
+}
+
+func newFakeGraph(t *testing.T) (*Client, *fakeGraph) {
+	t.Helper()
+	g := &fakeGraph{facts: make(map[string][]Fact)}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		g.requests = append(g.requests, r.Method+" "+r.URL.Path)
+		if err := g.serve(w, r); err != nil {
+			t.Errorf("%s %s: %v", r.Method, r.URL.Path, err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := NewClient("test-key", WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client, g
+}
+
+func (g *fakeGraph) addCollection(code string, facts ...Fact) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.collections = append(g.collections, Collection{ID: "id-" + code, Code: code, Name: strings.ToUpper(code)})
+	for _, f := range facts {
+		g.store(code, f)
+	}
+}
+
+func (g *fakeGraph) factsIn(code string) []Fact {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return slices.Clone(g.facts[code])
+}
+
+func (g *fakeGraph) byID(id string) (Collection, bool) {
+	for _, col := range g.collections {
+		if col.ID == id {
+			return col, true
+		}
+	}
+	return Collection{}, false
+}
+
+func (g *fakeGraph) serve(w http.ResponseWriter, r *http.Request) error {
+	write := func(v any) error {
+		return json.NewEncoder(w).Encode(map[string]any{"status": "ok", "result": v})
+	}
+	scope := func(col *string) string {
+		if col == nil {
+			return ""
+		}
+		return *col
+	}
+
+	rest, isCollections := strings.CutPrefix(r.URL.Path, "/v1/orgs/org1/collections/")
+	switch {
+	case isCollections && rest == "" && r.Method == http.MethodGet:
+		return write(g.collections)
+
+	case isCollections && rest == "" && r.Method == http.MethodPost:
+		var body createCollectionRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		if slices.ContainsFunc(g.collections, func(c Collection) bool { return c.Code == body.Code }) {
+			http.Error(w, "collection code already exists", http.StatusConflict)
+			return nil
+		}
+		col := Collection{ID: "id-" + body.Code, Code: body.Code, Name: body.Name, Description: body.Description}
+		g.collections = append(g.collections, col)
+		return write(col)
+
+	case isCollections && strings.HasSuffix(rest, "/facts"):
+		col, ok := g.byID(strings.TrimSuffix(rest, "/facts"))
+		if !ok || g.noListing {
+			http.Error(w, "not found", http.StatusNotFound)
+			return nil
+		}
+		facts := g.facts[col.Code]
+		start, _ := strconv.Atoi(r.URL.Query().Get("cursor"))
+		end := min(start+2, len(facts))
+		page := FactPage{Facts: slices.Clone(facts[start:end])}
+		if end < len(facts) {
+			page.NextCursor = strconv.Itoa(end)
+		}
+		return write(page)
+
+	case isCollections && r.Method == http.MethodGet:
+		col, ok := g.byID(rest)
+		if !ok {
+			http.Error(w, "not found", http.StatusNotFound)
+			return nil
+		}
+		n := len(g.facts[col.Code])
+		col.FactCount = &n
+		return write(col)
+
+	case isCollections && r.Method == http.MethodDelete:
+		col, ok := g.byID(rest)
+		if !ok {
+			http.Error(w, "not found", http.StatusNotFound)
+			return nil
+		}
+		n := len(g.facts[col.Code])
+		delete(g.facts, col.Code)
+		g.collections = slices.DeleteFunc(g.collections, func(c Collection) bool { return c.ID == col.ID })
+		return write(DeleteSummary{DeletedFacts: n})
+
+	case r.URL.Path == "/v1/remember_many":
+		if g.writes++; g.failWrites > 0 && g.writes > g.failWrites {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return nil
+		}
+		var body RememberManyRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		code := scope(body.Collection)
+		for _, f := range body.Facts {
+			g.store(code, Fact{
+				Subject: f.Subject, Predicate: f.Predicate, Object: f.Object,
+				Context: f.Context, Source: body.Source,
+				ValidFrom: f.ValidFrom, ValidTo: f.ValidTo, ObservedAt: f.ObservedAt,
+			})
+		}
+		return write(map[string]any{})
+
+	case r.URL.Path == "/v1/recall":
+		// Like the real search, recall also matches similar subjects.
+		var body RecallRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		var found []Fact
+		for _, f := range g.facts[scope(body.Collection)] {
+			if strings.Contains(strings.ToLower(f.Subject), strings.ToLower(body.Query)) &&
+				(body.Predicate == "" || f.Predicate == body.Predicate) && len(found) < body.Limit {
+				found = append(found, f)
+			}
+		}
+		return write(RecallResponse{Facts: found, Count: len(found)})
+
+	case r.URL.Path == "/v1/forget":
+		var body ForgetRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		code := scope(body.Collection)
+		g.facts[code] = slices.DeleteFunc(g.facts[code], func(f Fact) bool {
+			if body.ID != "" {
+				return f.ID == body.ID
+			}
+			return f.Subject == body.Subject && f.Predicate == body.Predicate && getObjectValue(f) == body.Object
+		})
+		return write(map[string]any{})
+	}
+	return fmt.Errorf("unexpected request")
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	client, g := newFakeGraph(t)
+	g.addCollection("crm",
+		Fact{Subject: "John", Predicate: "works_at", Object: "Acme", Source: "sync", ValidFrom: date(2022, 3), ValidTo: date(2025, 1)},
+		Fact{Subject: "John", Predicate: "age", Value: "42", Source: "sync", ObservedAt: date(2024, 6)},
+		Fact{Subject: "Acme", Predicate: "located_in", Object: "Berlin", Context: "HQ", Source: "manual"},
+	)
+	ctx := context.Background()
+
+	var archive bytes.Buffer
+	exported, err := client.ExportCollection(ctx, "org1", "crm", &archive)
+	if err != nil {
+		t.Fatalf("ExportCollection: %v", err)
+	}
+	if exported.Facts != 3 {
+		t.Errorf("expected 3 exported facts, got %d", exported.Facts)
+	}
+	lines := strings.Split(strings.TrimSpace(archive.String()), "\n")
+	if len(lines) != 5 || !strings.Contains(lines[0], `"format":"gomind-collection"`) || lines[4] != `{"type":"end","count":3}` {
+		t.Fatalf("unexpected archive:\n%s", archive.String())
+	}
+
+	var progress []ImportSummary
+	summary, err := client.ImportCollection(ctx, "org1", "crm-copy", bytes.NewReader(archive.Bytes()), ImportOptions{
+		Progress: func(s ImportSummary) { progress = append(progress, s) },
+	})
+	if err != nil {
+		t.Fatalf("ImportCollection: %v", err)
+	}
+	if summary.Read != 3 || summary.Imported != 3 {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+	// The source change from "sync" to "manual" splits the batch.
+	if len(progress) != 3 || progress[0].Imported != 2 {
+		t.Errorf("unexpected progress %+v", progress)
+	}
+
+	got := g.factsIn("crm-copy")
+	if len(got) != 3 || got[1].Object != "42" || got[2].Source != "manual" || got[2].Context != "HQ" {
+		t.Errorf("unexpected imported facts %+v", got)
+	}
+	if !reflect.DeepEqual(got[0].ValidFrom, date(2022, 3)) || !reflect.DeepEqual(got[0].ValidTo, date(2025, 1)) ||
+		!reflect.DeepEqual(got[1].ObservedAt, date(2024, 6)) || got[2].ValidFrom != nil {
+		t.Errorf("validity intervals were not preserved: %+v", got)
+	}
+	if _, err := client.GetCollectionByCode(ctx, "org1", "crm-copy"); err != nil {
+		t.Errorf("expected the target collection to be created: %v", err)
+	}
+}
+
+func TestImportConflicts(t *testing.T) {
+	archive := strings.Join([]string{
+		`{"type":"header","format":"gomind-collection","version":1,"collection":{"code":"crm","name":"CRM"}}`,
+		`{"type":"fact","subject":"John","predicate":"works_at","object":"Acme","context":"new"}`,
+		`{"type":"fact","subject":"Jane","predicate":"works_at","object":"Acme"}`,
+		`{"type":"end","count":2}`,
+	}, "\n")
+
+	tests := []struct {
+		policy      ConflictPolicy
+		wantErr     bool
+		wantSummary ImportSummary
+		wantContext string
+	}{
+		{ConflictSkip, false, ImportSummary{Read: 2, Imported: 1, Skipped: 1}, "old"},
+		{ConflictOverwrite, false, ImportSummary{Read: 2, Imported: 2, Overwritten: 1}, "new"},
+		// Conflicts are resolved when the batch is flushed, after both
+		// facts were read.
+		{ConflictFail, true, ImportSummary{Read: 2}, "old"},
+	}
+	for _, tc := range tests {
+		t.Run(string(tc.policy), func(t *testing.T) {
+			client, g := newFakeGraph(t)
+			g.addCollection("crm", Fact{Subject: "John", Predicate: "works_at", Object: "Acme", Context: "old"})
+
+			summary, err := client.ImportCollection(context.Background(), "org1", "", strings.NewReader(archive), ImportOptions{Conflict: tc.policy})
+			var conflict *ImportConflictError
+			if tc.wantErr != errors.As(err, &conflict) {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if *summary != tc.wantSummary {
+				t.Errorf("unexpected summary %+v, want %+v", *summary, tc.wantSummary)
+			}
+			for _, f := range g.factsIn("crm") {
+				if f.Subject == "John" && f.Context != tc.wantContext {
+					t.Errorf("expected John's context %q, got %q", tc.wantContext, f.Context)
+				}
+			}
+		})
+	}
+}
+
+func TestImportChecksConflictsPerBatch(t *testing.T) {
+	archive := strings.Join([]string{
+		`{"type":"header","format":"gomind-collection","version":1,"collection":{"code":"crm","name":"CRM"}}`,
+		`{"type":"fact","subject":"John","predicate":"works_at","object":"Acme"}`,
+		`{"type":"fact","subject":"John","predicate":"works_at","object":"Acme"}`,
+		`{"type":"fact","subject":"john","predicate":"works_at","object":"Acme"}`,
+		`{"type":"end","count":3}`,
+	}, "\n")
+	client, g := newFakeGraph(t)
+	g.addCollection("crm", Fact{Subject: "Johnny", Predicate: "works_at", Object: "Acme"})
+
+	summary, err := client.ImportCollection(context.Background(), "org1", "", strings.NewReader(archive), ImportOptions{})
+	if err != nil {
+		t.Fatalf("ImportCollection: %v", err)
+	}
+	// The repeated fact is skipped; "john" and "Johnny" are other entities.
+	if want := (ImportSummary{Read: 3, Imported: 2, Skipped: 1}); *summary != want {
+		t.Errorf("unexpected summary %+v, want %+v", *summary, want)
+	}
+	for _, req := range g.requests {
+		if strings.HasSuffix(req, "/facts") {
+			t.Errorf("import must not list the whole target collection, got %s", req)
+		}
+	}
+}
+
+func TestListFactsPageUnsupported(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/orgs/org1/collections/" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"status":"ok","result":[{"id":"id-crm","code":"crm"}]}`))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+	client, err := NewClient("test-key", WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	_, err = client.ListFactsPage(context.Background(), "org1", "crm", ListFactsOptions{})
+	var apiErr *APIError
+	if !errors.Is(err, ErrFactListingUnsupported) || !errors.As(err, &apiErr) {
+		t.Errorf("expected ErrFactListingUnsupported wrapping the API error, got %v", err)
+	}
+}
+
+func TestImportInvalidArchive(t *testing.T) {
+	tests := []struct {
+		name    string
+		archive string
+		want    string
+	}{
+		{"not an archive", `{"subject":"John"}`, "not a gomind-collection archive"},
+		{"future version", `{"type":"header","format":"gomind-collection","version":2,"collection":{"code":"crm"}}`, "unsupported archive version 2"},
+		{"truncated", `{"type":"header","format":"gomind-collection","version":1,"collection":{"code":"crm"}}` + "\n" +
+			`{"type":"fact","subject":"John","predicate":"works_at","object":"Acme"}`, "without an end record"},
+		{"count mismatch", `{"type":"header","format":"gomind-collection","version":1,"collection":{"code":"crm"}}` + "\n" +
+			`{"type":"end","count":4}`, "declares 4 facts but contains 0"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client, _ := newFakeGraph(t)
+			_, err := client.ImportCollection(context.Background(), "org1", "", strings.NewReader(tc.archive), ImportOptions{})
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("expected error containing %q, got %v", tc.want, err)
+			}
+			if tc.name == "truncated" && !errors.Is(err, io.ErrUnexpectedEOF) {
+				t.Errorf("expected io.ErrUnexpectedEOF, got %v", err)
+			}
+		})
+	}
+}
+
+// TestImportKeepsIntervalsOfSameTriple verifies that a triple stored for
+// several periods is not treated as a conflict with itself.
+func TestImportKeepsIntervalsOfSameTriple(t *testing.T) {
+	client, g := newFakeGraph(t)
+	g.addCollection("hr",
+		Fact{Subject: "John", Predicate: "works_at", Object: "Acme", ValidFrom: date(2015, 1), ValidTo: date(2018, 1)},
+		Fact{Subject: "John", Predicate: "works_at", Object: "Acme", ValidFrom: date(2022, 3)},
+	)
+	ctx := context.Background()
+
+	var archive bytes.Buffer
+	if _, err := client.ExportCollection(ctx, "org1", "hr", &archive); err != nil {
+		t.Fatalf("ExportCollection: %v", err)
+	}
+	for range 2 {
+		if _, err := client.ImportCollection(ctx, "org1", "hr-copy", bytes.NewReader(archive.Bytes()), ImportOptions{}); err != nil {
+			t.Fatalf("ImportCollection: %v", err)
+		}
+	}
+	got := g.factsIn("hr-copy")
+	if len(got) != 2 || got[0].ValidTo == nil || got[1].ValidTo != nil {
+		t.Errorf("expected both periods exactly once, got %+v", got)
+	}
+}
+
+// TestImportOverwriteKeepsOtherIntervals verifies that overwriting a
+// fact forgets only the stored copy it conflicts with.
+func TestImportOverwriteKeepsOtherIntervals(t *testing.T) {
+	client, g := newFakeGraph(t)
+	g.addCollection("hr",
+		Fact{Subject: "John", Predicate: "works_at", Object: "Acme", ValidFrom: date(2015, 1), ValidTo: date(2018, 1)},
+		Fact{Subject: "John", Predicate: "works_at", Object: "Acme", ValidFrom: date(2022, 3), Context: "old"},
+	)
+	archive := strings.Join([]string{
+		`{"type":"header","format":"gomind-collection","version":1,"collection":{"code":"hr","name":"HR"}}`,
+		`{"type":"fact","subject":"John","predicate":"works_at","object":"Acme","context":"new","valid_from":"2022-03-01T00:00:00Z"}`,
+		`{"type":"end","count":1}`,
+	}, "\n")
+
+	summary, err := client.ImportCollection(context.Background(), "org1", "hr", strings.NewReader(archive), ImportOptions{Conflict: ConflictOverwrite})
+	if err != nil {
+		t.Fatalf("ImportCollection: %v", err)
+	}
+	if summary.Overwritten != 1 {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+	got := g.factsIn("hr")
+	if len(got) != 2 || got[0].ValidTo == nil || got[1].Context != "new" {
+		t.Errorf("expected the ended period to survive the overwrite, got %+v", got)
+	}
+}
+
+// TestImportBypassesOntology verifies archived facts are restored as
+// they were, even with a strict upserting ontology installed.
+func TestImportBypassesOntology(t *testing.T) {
+	client, g := newFakeGraph(t)
+	o, err := NewOntology(PredicateDef{Name: "works_at", Aliases: []string{"employed_by"}, Cardinality: Single})
+	if err != nil {
+		t.Fatalf("NewOntology: %v", err)
+	}
+	o.Strict, o.UpsertSingle = true, true
+	client.ontology = o
+
+	archive := strings.Join([]string{
+		`{"type":"header","format":"gomind-collection","version":1,"collection":{"code":"hr","name":"HR"}}`,
+		`{"type":"fact","subject":"John","predicate":"employed_by","object":"Acme"}`,
+		`{"type":"fact","subject":"John","predicate":"works_at","object":"Globex"}`,
+		`{"type":"fact","subject":"John","predicate":"likes","object":"tea"}`,
+		`{"type":"end","count":3}`,
+	}, "\n")
+	if _, err := client.ImportCollection(context.Background(), "org1", "hr", strings.NewReader(archive), ImportOptions{}); err != nil {
+		t.Fatalf("ImportCollection: %v", err)
+	}
+	got := g.factsIn("hr")
+	if len(got) != 3 || got[0].Predicate != "employed_by" || got[1].Object != "Globex" {
+		t.Errorf("expected the archive to be restored unchanged, got %+v", got)
+	}
+}
@@ -17,6 +17,22 @@ type Client struct {
 	collection string
 	httpClient *http.Client
 	logger     Logger
+	toolPolicy ToolPolicy
+	usage      *UsageAccountant
+	ontology   *Ontology
+
+	collectionIDs collectionIDCache
+}
+
+// APIError is returned when the Gomind API responds with a non-2xx
+// status. Use errors.As to inspect the status code.
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Body)
 }
 
 // NewClient creates a new Gomind client.
@@ -41,8 +57,8 @@ func NewClient(apiKey string, opts ...Option) (*Client, error) {
 	return c, nil
 }
 
-// doRequest performs an HTTP request to the Gomind API.
-func (c *Client) doRequest(ctx context.Context, method, endpoint string, body any) ([]byte, error) {
+// newRequest builds an authenticated JSON request to the Gomind API.
+func (c *Client) newRequest(ctx context.Context, method, endpoint string, body any) (*http.Request, error) {
 	url := fmt.Sprintf("%s%s", c.baseURL, endpoint)
 
 	var reqBody io.Reader
@@ -62,6 +78,15 @@ func (c *Client) doRequest(ctx context.Context, method, endpoint string, body an
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
+	return req, nil
+}
+
+// doRequest performs an HTTP request to the Gomind API.
+func (c *Client) doRequest(ctx context.Context, method, endpoint string, body any) ([]byte, error) {
+	req, err := c.newRequest(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -75,12 +100,41 @@ func (c *Client) doRequest(ctx context.Context, method, endpoint string, body an
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(respBody))
+		return nil, &APIError{StatusCode: resp.StatusCode, Body: string(respBody)}
 	}
 
 	return respBody, nil
 }
 
+// postStream performs a POST request expecting a server-sent-events
+// response and returns the open response for the caller to consume and
+// close. The client timeout does not apply, since it would cut long
+// streams short; cancel ctx to abort.
+func (c *Client) postStream(ctx context.Context, endpoint string, body any) (*http.Response, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	streamClient := *c.httpClient
+	streamClient.Timeout = 0
+	resp, err := streamClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return nil, &APIError{StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+	return resp, nil
+}
+
 // post performs a POST request to the Gomind API.
 func (c *Client) post(ctx context.Context, endpoint string, body any) ([]byte, error) {
 	return c.doRequest(ctx, http.MethodPost, endpoint, body)
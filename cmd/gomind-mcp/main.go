@@ -0,0 +1,114 @@
+// Command gomind-mcp serves the Gomind tools to local agents over the
+// Model Context Protocol.
+//
+// Usage:
+//
+//	GOMIND_API_KEY=... gomind-mcp [-transport stdio|http] [-addr 127.0.0.1:8080]
+//	    [-org ORG_ID] [-collection CODE] [-policy full|append-only|read-only]
+//	    [-allow-origin https://app.example.com,...]
+//
+// The policy defaults to full on stdio and to read-only on http, where
+// write tools have to be enabled explicitly with -policy. The http
+// transport listens on loopback only unless -addr says otherwise.
+//
+// GOMIND_BASE_URL overrides the API base URL.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	gomind "github.com/ingate/gomind-go-sdk"
+	"github.com/ingate/gomind-go-sdk/mcp"
+)
+
+func main() {
+	transport := flag.String("transport", "stdio", "transport to serve: stdio or http")
+	addr := flag.String("addr", "127.0.0.1:8080", "listen address for the http transport")
+	path := flag.String("path", "/mcp", "endpoint path for the http transport")
+	orgID := flag.String("org", "", "org ID whose collections are exposed as resources")
+	collection := flag.String("collection", "", "default collection code for memory operations")
+	policy := flag.String("policy", "", "tool policy: full, append-only or read-only (default full on stdio, read-only on http)")
+	allowOrigin := flag.String("allow-origin", "", "comma-separated browser origins allowed on the http transport besides loopback")
+	flag.Parse()
+
+	// stdout carries the protocol on the stdio transport, so all logging
+	// goes to stderr.
+	log.SetOutput(os.Stderr)
+
+	var origins []string
+	if *allowOrigin != "" {
+		origins = strings.Split(*allowOrigin, ",")
+	}
+	if err := run(*transport, *addr, *path, *orgID, *collection, *policy, origins); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(transport, addr, path, orgID, collection, policyName string, origins []string) error {
+	if policyName == "" {
+		// Anyone who can reach the port acts with the operator's API
+		// key, so HTTP is read-only unless asked otherwise.
+		policyName = "full"
+		if transport == "http" {
+			policyName = "read-only"
+		}
+	}
+	policy, err := parsePolicy(policyName)
+	if err != nil {
+		return err
+	}
+
+	client, err := gomind.NewClient(os.Getenv("GOMIND_API_KEY"),
+		gomind.WithBaseURL(os.Getenv("GOMIND_BASE_URL")),
+		gomind.WithCollection(collection),
+		gomind.WithToolPolicy(policy),
+	)
+	if err != nil {
+		return err
+	}
+
+	server := mcp.NewServer(client, mcp.Options{Name: "gomind-mcp", OrgID: orgID, AllowedOrigins: origins})
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	switch transport {
+	case "stdio":
+		return server.ServeStdio(ctx, os.Stdin, os.Stdout)
+	case "http":
+		mux := http.NewServeMux()
+		mux.Handle(path, server)
+		srv := &http.Server{Addr: addr, Handler: mux}
+		go func() {
+			<-ctx.Done()
+			_ = srv.Shutdown(context.Background())
+		}()
+		log.Printf("gomind-mcp listening on %s%s with %s policy", addr, path, policyName)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			return err
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown transport %q", transport)
+	}
+}
+
+func parsePolicy(name string) (gomind.ToolPolicy, error) {
+	switch name {
+	case "full":
+		return gomind.FullPolicy(), nil
+	case "append-only":
+		return gomind.AppendOnlyPolicy(), nil
+	case "read-only":
+		return gomind.ReadOnlyPolicy(), nil
+	}
+	return gomind.ToolPolicy{}, fmt.Errorf("unknown policy %q", name)
+}
@@ -0,0 +1,131 @@
+package gomind
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ErrDeleteNotConfirmed is returned by SafeDeleteCollection when the
+// confirmation token does not match the collection code.
+var ErrDeleteNotConfirmed = errors.New("collection delete not confirmed")
+
+// SafeDeleteOptions configures SafeDeleteCollection.
+type SafeDeleteOptions struct {
+	// DryRun reports what would be deleted without deleting anything.
+	// No confirmation is needed for a dry run.
+	DryRun bool
+
+	// Confirm must equal DeleteConfirmation(code) for the delete to
+	// proceed.
+	Confirm string
+
+	// Backup, when set, is called before deleting; the collection is
+	// exported to the returned writer, which is then closed. Any error
+	// aborts the delete. See BackupToDir.
+	Backup func(col *Collection) (io.WriteCloser, error)
+}
+
+// DeleteConfirmation returns the token SafeDeleteCollection requires to
+// delete the collection with the given code. Deriving it from the code
+// means a script cannot delete one collection while holding the token
+// for another.
+func DeleteConfirmation(code string) string {
+	return "delete:" + code
+}
+
+// SafeDeleteCollection deletes the collection with the given code after
+// checking opts.Confirm and running the optional backup. With DryRun set
+// it returns the summary the delete would produce: the fact count from
+// GetCollection, and the number of distinct entities referenced by the
+// collection's facts. The entity count is left at zero when the server
+// does not support listing facts (see AllFacts).
+func (c *Client) SafeDeleteCollection(ctx context.Context, orgID, code string, opts SafeDeleteOptions) (*DeleteSummary, error) {
+	col, err := c.GetCollectionByCode(ctx, orgID, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.DryRun {
+		return c.deletePreview(ctx, orgID, col)
+	}
+
+	if opts.Confirm != DeleteConfirmation(code) {
+		c.logger.Error("Gomind SafeDeleteCollection rejected", "orgID", orgID, "code", code)
+		return nil, fmt.Errorf("%w: Confirm must be DeleteConfirmation(%q)", ErrDeleteNotConfirmed, code)
+	}
+
+	if opts.Backup != nil {
+		if err := c.backupCollection(ctx, orgID, col, opts.Backup); err != nil {
+			return nil, fmt.Errorf("backup before delete failed: %w", err)
+		}
+	}
+
+	summary, err := c.DeleteCollection(ctx, orgID, col.ID)
+	if err != nil {
+		return nil, err
+	}
+	c.logger.Info("Gomind SafeDeleteCollection success",
+		"orgID", orgID,
+		"code", code,
+		"deletedFacts", summary.DeletedFacts,
+		"deletedEntities", summary.DeletedEntities,
+	)
+	return summary, nil
+}
+
+// deletePreview estimates the DeleteSummary for col. The fact count
+// comes from GetCollection; the entity count needs the facts themselves
+// and stays zero on servers that cannot list them.
+func (c *Client) deletePreview(ctx context.Context, orgID string, col *Collection) (*DeleteSummary, error) {
+	summary := &DeleteSummary{}
+	if col.FactCount != nil {
+		summary.DeletedFacts = *col.FactCount
+	}
+	entities := make(map[string]bool)
+	facts := 0
+	for fact, err := range c.AllFacts(ctx, orgID, col.Code, ListFactsOptions{}) {
+		if errors.Is(err, ErrFactListingUnsupported) && col.FactCount != nil {
+			c.logger.Info("Gomind SafeDeleteCollection preview without entity count", "orgID", orgID, "code", col.Code)
+			return summary, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		facts++
+		entities[fact.Subject] = true
+		if fact.Object != "" {
+			entities[fact.Object] = true
+		}
+	}
+	if col.FactCount == nil {
+		summary.DeletedFacts = facts
+	}
+	summary.DeletedEntities = len(entities)
+	return summary, nil
+}
+
+func (c *Client) backupCollection(ctx context.Context, orgID string, col *Collection, backup func(*Collection) (io.WriteCloser, error)) error {
+	w, err := backup(col)
+	if err != nil {
+		return err
+	}
+	_, err = c.ExportCollection(ctx, orgID, col.Code, w)
+	if closeErr := w.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// BackupToDir returns a SafeDeleteOptions.Backup hook that writes each
+// collection to dir as <code>-<UTC timestamp>.ndjson.
+func BackupToDir(dir string) func(col *Collection) (io.WriteCloser, error) {
+	return func(col *Collection) (io.WriteCloser, error) {
+		name := fmt.Sprintf("%s-%s.ndjson", col.Code, time.Now().UTC().Format("20060102T150405Z"))
+		return os.Create(filepath.Join(dir, name))
+	}
+}
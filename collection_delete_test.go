@@ -0,0 +1,111 @@
+package gomind
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSafeDeleteCollectionDryRun(t *testing.T) {
+	client, g := newFakeGraph(t)
+	g.addCollection("prod",
+		Fact{Subject: "John", Predicate: "works_at", Object: "Acme"},
+		Fact{Subject: "Jane", Predicate: "works_at", Object: "Acme"},
+		Fact{Subject: "John", Predicate: "age", Value: "42"},
+	)
+
+	summary, err := client.SafeDeleteCollection(context.Background(), "org1", "prod", SafeDeleteOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("SafeDeleteCollection: %v", err)
+	}
+	if summary.DeletedFacts != 3 || summary.DeletedEntities != 3 {
+		t.Errorf("unexpected dry-run summary %+v", summary)
+	}
+	if len(g.factsIn("prod")) != 3 {
+		t.Error("dry run must not delete anything")
+	}
+}
+
+func TestSafeDeleteCollectionDryRunWithoutListing(t *testing.T) {
+	client, g := newFakeGraph(t)
+	g.addCollection("prod",
+		Fact{Subject: "John", Predicate: "works_at", Object: "Acme"},
+		Fact{Subject: "Jane", Predicate: "works_at", Object: "Acme"},
+	)
+	g.noListing = true
+
+	summary, err := client.SafeDeleteCollection(context.Background(), "org1", "prod", SafeDeleteOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("SafeDeleteCollection: %v", err)
+	}
+	if summary.DeletedFacts != 2 || summary.DeletedEntities != 0 {
+		t.Errorf("unexpected dry-run summary %+v", summary)
+	}
+}
+
+func TestSafeDeleteCollectionConfirmation(t *testing.T) {
+	client, g := newFakeGraph(t)
+	g.addCollection("prod", Fact{Subject: "John", Predicate: "works_at", Object: "Acme"})
+	g.addCollection("staging")
+	ctx := context.Background()
+
+	for _, confirm := range []string{"", DeleteConfirmation("staging")} {
+		_, err := client.SafeDeleteCollection(ctx, "org1", "prod", SafeDeleteOptions{Confirm: confirm})
+		if !errors.Is(err, ErrDeleteNotConfirmed) {
+			t.Errorf("Confirm %q: expected ErrDeleteNotConfirmed, got %v", confirm, err)
+		}
+	}
+	if len(g.factsIn("prod")) != 1 {
+		t.Fatal("unconfirmed delete removed facts")
+	}
+
+	summary, err := client.SafeDeleteCollection(ctx, "org1", "prod", SafeDeleteOptions{Confirm: DeleteConfirmation("prod")})
+	if err != nil {
+		t.Fatalf("SafeDeleteCollection: %v", err)
+	}
+	if summary.DeletedFacts != 1 {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+	if _, err := client.GetCollectionByCode(ctx, "org1", "prod"); !errors.Is(err, ErrCollectionNotFound) {
+		t.Errorf("expected the collection to be gone, got %v", err)
+	}
+}
+
+func TestSafeDeleteCollectionBackup(t *testing.T) {
+	client, g := newFakeGraph(t)
+	g.addCollection("prod", Fact{Subject: "John", Predicate: "works_at", Object: "Acme"})
+	ctx := context.Background()
+
+	failing := func(*Collection) (io.WriteCloser, error) { return nil, errors.New("disk full") }
+	_, err := client.SafeDeleteCollection(ctx, "org1", "prod", SafeDeleteOptions{
+		Confirm: DeleteConfirmation("prod"),
+		Backup:  failing,
+	})
+	if err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Fatalf("expected the backup error, got %v", err)
+	}
+	if len(g.factsIn("prod")) != 1 {
+		t.Fatal("a failed backup must abort the delete")
+	}
+
+	dir := t.TempDir()
+	if _, err := client.SafeDeleteCollection(ctx, "org1", "prod", SafeDeleteOptions{
+		Confirm: DeleteConfirmation("prod"),
+		Backup:  BackupToDir(dir),
+	}); err != nil {
+		t.Fatalf("SafeDeleteCollection: %v", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "prod-*.ndjson"))
+	if len(files) != 1 {
+		t.Fatalf("expected one backup file, got %v", files)
+	}
+	data, _ := os.ReadFile(files[0])
+	if !strings.Contains(string(data), `"subject":"John"`) || !strings.Contains(string(data), `{"type":"end","count":1}`) {
+		t.Errorf("unexpected backup contents:\n%s", data)
+	}
+}
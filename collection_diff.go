@@ -0,0 +1,195 @@
+package gomind
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+)
+
+// CollectionRef names a collection in an org.
+type CollectionRef struct {
+	OrgID string
+	Code  string
+}
+
+// FactChange is a fact that differs between two collections.
+type FactChange struct {
+	Before Fact
+	After  Fact
+}
+
+// CollectionDiff is the result of DiffCollections. Each list is sorted
+// by subject, predicate and object.
+type CollectionDiff struct {
+	// Added lists facts only in b.
+	Added []Fact
+	// Removed lists facts only in a.
+	Removed []Fact
+	// Changed lists facts present on both sides with a different context
+	// or source, facts whose validity interval changed, and single-valued
+	// subject/predicate pairs whose object changed.
+	Changed []FactChange
+	// Unchanged counts identical facts.
+	Unchanged int
+}
+
+// Empty reports whether the collections hold the same facts.
+func (d *CollectionDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffCollections compares the facts of collection a (before) with those
+// of collection b (after). Facts are matched by subject, predicate,
+// object (or value) and validity interval. Only a is held in memory; b
+// is streamed.
+func (c *Client) DiffCollections(ctx context.Context, a, b CollectionRef) (*CollectionDiff, error) {
+	before := make(map[factKey]Fact)
+	for fact, err := range c.AllFacts(ctx, a.OrgID, a.Code, ListFactsOptions{}) {
+		if err != nil {
+			return nil, err
+		}
+		before[keyOf(fact)] = fact
+	}
+
+	diff := &CollectionDiff{}
+	var added []Fact
+	for fact, err := range c.AllFacts(ctx, b.OrgID, b.Code, ListFactsOptions{}) {
+		if err != nil {
+			return nil, err
+		}
+		key := keyOf(fact)
+		old, ok := before[key]
+		if !ok {
+			added = append(added, fact)
+			continue
+		}
+		delete(before, key)
+		if old.Context != fact.Context || old.Source != fact.Source {
+			diff.Changed = append(diff.Changed, FactChange{Before: old, After: fact})
+		} else {
+			diff.Unchanged++
+		}
+	}
+
+	// A triple that lost exactly one interval and gained exactly one had
+	// its interval changed.
+	type tripleKey struct{ subject, predicate, object string }
+	tripleOf := func(f Fact) tripleKey { return tripleKey{f.Subject, f.Predicate, getObjectValue(f)} }
+	removedTriples := make(map[tripleKey][]factKey)
+	for key, fact := range before {
+		removedTriples[tripleOf(fact)] = append(removedTriples[tripleOf(fact)], key)
+	}
+	addedTriples := make(map[tripleKey][]int)
+	for i, fact := range added {
+		addedTriples[tripleOf(fact)] = append(addedTriples[tripleOf(fact)], i)
+	}
+	var unmatched []Fact
+	for i, fact := range added {
+		removed, gained := removedTriples[tripleOf(fact)], addedTriples[tripleOf(fact)]
+		if len(removed) != 1 || len(gained) != 1 {
+			unmatched = append(unmatched, added[i])
+			continue
+		}
+		diff.Changed = append(diff.Changed, FactChange{Before: before[removed[0]], After: fact})
+		delete(before, removed[0])
+	}
+	added = unmatched
+
+	// A subject/predicate pair that lost exactly one object and gained
+	// exactly one is reported as a change rather than a removal plus an
+	// addition.
+	type pairKey struct{ subject, predicate string }
+	removedBy := make(map[pairKey][]Fact)
+	for _, fact := range before {
+		k := pairKey{fact.Subject, fact.Predicate}
+		removedBy[k] = append(removedBy[k], fact)
+	}
+	addedBy := make(map[pairKey][]Fact)
+	for _, fact := range added {
+		k := pairKey{fact.Subject, fact.Predicate}
+		addedBy[k] = append(addedBy[k], fact)
+	}
+	for k, removed := range removedBy {
+		if gained := addedBy[k]; len(removed) == 1 && len(gained) == 1 {
+			diff.Changed = append(diff.Changed, FactChange{Before: removed[0], After: gained[0]})
+			delete(addedBy, k)
+			continue
+		}
+		diff.Removed = append(diff.Removed, removed...)
+	}
+	for _, gained := range addedBy {
+		diff.Added = append(diff.Added, gained...)
+	}
+
+	slices.SortFunc(diff.Added, compareFacts)
+	slices.SortFunc(diff.Removed, compareFacts)
+	slices.SortFunc(diff.Changed, func(x, y FactChange) int { return compareFacts(x.Before, y.Before) })
+	return diff, nil
+}
+
+func compareFacts(x, y Fact) int {
+	return cmp.Or(
+		cmp.Compare(x.Subject, y.Subject),
+		cmp.Compare(x.Predicate, y.Predicate),
+		cmp.Compare(getObjectValue(x), getObjectValue(y)),
+	)
+}
+
+// CloneCollection copies every fact of srcCode into a new collection
+// dstCode with the same name and description, streaming an archive
+// between ExportCollection and ImportCollection. The target is created
+// up front and the clone fails if dstCode already exists (the server
+// answers 409), so a clone is always an exact snapshot.
+//
+// If the copy fails part way, the collection this call created is
+// deleted again by ID so the clone can simply be retried; a collection
+// created by anyone else is never touched. An error from that cleanup
+// is joined to the returned error.
+func (c *Client) CloneCollection(ctx context.Context, orgID, srcCode, dstCode string) (*ImportSummary, error) {
+	src, err := c.GetCollectionByCode(ctx, orgID, srcCode)
+	if err != nil {
+		return nil, err
+	}
+	dst, err := c.CreateCollection(ctx, orgID, dstCode, src.Name, src.Description)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict {
+			return nil, fmt.Errorf("clone target collection %q already exists", dstCode)
+		}
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+	exported := make(chan error, 1)
+	go func() {
+		_, err := c.ExportCollection(ctx, orgID, srcCode, pw)
+		pw.CloseWithError(err)
+		exported <- err
+	}()
+
+	summary, err := c.ImportCollection(ctx, orgID, dstCode, pr, ImportOptions{})
+	pr.CloseWithError(err)
+	// Report whichever side failed first: an export failure surfaces in
+	// the import as a read error, an import failure in the export as a
+	// write error.
+	exportErr := <-exported
+	if err == nil {
+		err = exportErr
+	} else if exportErr != nil && errors.Is(err, exportErr) {
+		err = exportErr
+	}
+	if err != nil {
+		c.logger.Error("Gomind CloneCollection failed", "error", err, "orgID", orgID, "src", srcCode, "dst", dstCode)
+		if _, delErr := c.DeleteCollection(context.WithoutCancel(ctx), orgID, dst.ID); delErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to remove partial clone %q: %w", dstCode, delErr))
+		}
+		return summary, err
+	}
+
+	c.logger.Info("Gomind CloneCollection success", "orgID", orgID, "src", srcCode, "dst", dstCode, "facts", summary.Imported)
+	return summary, nil
+}
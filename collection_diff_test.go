@@ -0,0 +1,160 @@
+package gomind
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDiffCollections(t *testing.T) {
+	client, g := newFakeGraph(t)
+	g.addCollection("before",
+		Fact{Subject: "John", Predicate: "works_at", Object: "Acme"},
+		Fact{Subject: "John", Predicate: "knows", Object: "Jane"},
+		Fact{Subject: "John", Predicate: "knows", Object: "Bob"},
+		Fact{Subject: "Acme", Predicate: "located_in", Object: "Berlin", Context: "HQ"},
+		Fact{Subject: "Jane", Predicate: "age", Value: "41"},
+	)
+	g.addCollection("after",
+		Fact{Subject: "John", Predicate: "works_at", Object: "Globex"},
+		Fact{Subject: "John", Predicate: "knows", Object: "Alice"},
+		Fact{Subject: "Acme", Predicate: "located_in", Object: "Berlin", Context: "head office"},
+		Fact{Subject: "Jane", Predicate: "age", Value: "41"},
+	)
+
+	diff, err := client.DiffCollections(context.Background(),
+		CollectionRef{OrgID: "org1", Code: "before"},
+		CollectionRef{OrgID: "org1", Code: "after"},
+	)
+	if err != nil {
+		t.Fatalf("DiffCollections: %v", err)
+	}
+
+	if len(diff.Added) != 1 || diff.Added[0].Object != "Alice" {
+		t.Errorf("unexpected added %+v", diff.Added)
+	}
+	if len(diff.Removed) != 2 || diff.Removed[0].Object != "Bob" || diff.Removed[1].Object != "Jane" {
+		t.Errorf("unexpected removed %+v", diff.Removed)
+	}
+	if len(diff.Changed) != 2 {
+		t.Fatalf("unexpected changed %+v", diff.Changed)
+	}
+	if c := diff.Changed[0]; c.Before.Context != "HQ" || c.After.Context != "head office" {
+		t.Errorf("expected context change first, got %+v", c)
+	}
+	if c := diff.Changed[1]; c.Before.Object != "Acme" || c.After.Object != "Globex" {
+		t.Errorf("expected works_at change, got %+v", c)
+	}
+	if diff.Unchanged != 1 || diff.Empty() {
+		t.Errorf("unexpected unchanged count %d", diff.Unchanged)
+	}
+}
+
+func TestDiffCollectionsIntervals(t *testing.T) {
+	client, g := newFakeGraph(t)
+	g.addCollection("before",
+		Fact{Subject: "John", Predicate: "works_at", Object: "Acme", ValidFrom: date(2022, 3)},
+		Fact{Subject: "John", Predicate: "lives_in", Object: "Berlin", ValidFrom: date(2010, 1), ValidTo: date(2015, 1)},
+		Fact{Subject: "John", Predicate: "lives_in", Object: "Berlin", ValidFrom: date(2020, 1)},
+	)
+	g.addCollection("after",
+		Fact{Subject: "John", Predicate: "works_at", Object: "Acme", ValidFrom: date(2022, 3), ValidTo: date(2025, 1)},
+		Fact{Subject: "John", Predicate: "lives_in", Object: "Berlin", ValidFrom: date(2010, 1), ValidTo: date(2015, 1)},
+		Fact{Subject: "John", Predicate: "lives_in", Object: "Berlin", ValidFrom: date(2020, 1)},
+	)
+
+	diff, err := client.DiffCollections(context.Background(), CollectionRef{"org1", "before"}, CollectionRef{"org1", "after"})
+	if err != nil {
+		t.Fatalf("DiffCollections: %v", err)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0].Before.ValidTo != nil || diff.Changed[0].After.ValidTo == nil {
+		t.Errorf("expected the ended interval as a change, got %+v", diff)
+	}
+	if len(diff.Added) != 0 || len(diff.Removed) != 0 || diff.Unchanged != 2 {
+		t.Errorf("unexpected diff %+v", diff)
+	}
+}
+
+func TestCloneCollection(t *testing.T) {
+	client, g := newFakeGraph(t)
+	g.addCollection("kb",
+		Fact{Subject: "John", Predicate: "works_at", Object: "Acme", Source: "sync", ValidFrom: date(2022, 3)},
+		Fact{Subject: "Acme", Predicate: "located_in", Object: "Berlin", Source: "sync"},
+		Fact{Subject: "Jane", Predicate: "age", Value: "41", Source: "manual"},
+	)
+	ctx := context.Background()
+
+	summary, err := client.CloneCollection(ctx, "org1", "kb", "kb-snapshot")
+	if err != nil {
+		t.Fatalf("CloneCollection: %v", err)
+	}
+	if summary.Imported != 3 {
+		t.Errorf("expected 3 cloned facts, got %+v", summary)
+	}
+
+	diff, err := client.DiffCollections(ctx, CollectionRef{"org1", "kb"}, CollectionRef{"org1", "kb-snapshot"})
+	if err != nil {
+		t.Fatalf("DiffCollections: %v", err)
+	}
+	if !diff.Empty() {
+		t.Errorf("expected an identical clone, got %+v", diff)
+	}
+	if cloned := g.factsIn("kb-snapshot"); cloned[0].ValidFrom == nil || !cloned[0].ValidFrom.Equal(*date(2022, 3)) {
+		t.Errorf("clone lost the validity interval: %+v", cloned[0])
+	}
+
+	if _, err := client.CloneCollection(ctx, "org1", "kb", "kb-snapshot"); err == nil {
+		t.Error("expected cloning onto an existing collection to fail")
+	}
+	if _, err := client.CloneCollection(ctx, "org1", "missing", "copy"); !errors.Is(err, ErrCollectionNotFound) {
+		t.Errorf("expected ErrCollectionNotFound for a missing source, got %v", err)
+	}
+}
+
+func TestCloneCollectionFailureCleansUp(t *testing.T) {
+	client, g := newFakeGraph(t)
+	g.addCollection("kb",
+		Fact{Subject: "John", Predicate: "works_at", Object: "Acme", Source: "sync"},
+		Fact{Subject: "Jane", Predicate: "age", Value: "41", Source: "manual"},
+	)
+	// The source change splits the copy into two batches; the second fails.
+	g.failWrites = 1
+	ctx := context.Background()
+
+	if _, err := client.CloneCollection(ctx, "org1", "kb", "kb-snapshot"); err == nil {
+		t.Fatal("expected the clone to fail")
+	}
+	if _, err := client.GetCollectionByCode(ctx, "org1", "kb-snapshot"); !errors.Is(err, ErrCollectionNotFound) {
+		t.Errorf("expected the partial clone to be removed, got %v", err)
+	}
+
+	g.failWrites = 0
+	summary, err := client.CloneCollection(ctx, "org1", "kb", "kb-snapshot")
+	if err != nil {
+		t.Fatalf("retrying CloneCollection: %v", err)
+	}
+	if summary.Imported != 2 || len(g.factsIn("kb-snapshot")) != 2 {
+		t.Errorf("unexpected retried clone %+v", summary)
+	}
+}
+
+// TestCloneCollectionLeavesForeignTarget verifies a clone never deletes
+// a target collection it did not create itself.
+func TestCloneCollectionLeavesForeignTarget(t *testing.T) {
+	client, g := newFakeGraph(t)
+	g.addCollection("kb", Fact{Subject: "John", Predicate: "works_at", Object: "Acme"})
+	g.addCollection("kb-snapshot", Fact{Subject: "Jane", Predicate: "works_at", Object: "Globex"})
+
+	if _, err := client.CloneCollection(context.Background(), "org1", "kb", "kb-snapshot"); err == nil {
+		t.Fatal("expected cloning onto an existing collection to fail")
+	}
+	if got := g.factsIn("kb-snapshot"); len(got) != 1 || got[0].Subject != "Jane" {
+		t.Errorf("existing target was modified: %+v", got)
+	}
+	for _, req := range g.requests {
+		if strings.HasPrefix(req, "DELETE ") || strings.HasSuffix(req, "/remember_many") {
+			t.Errorf("unexpected request %s", req)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package gomind
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ErrFactListingUnsupported is returned by ListFactsPage, and so by
+// AllFacts and the collection operations built on it, when the server
+// does not offer the collection fact listing endpoint.
+var ErrFactListingUnsupported = errors.New("server does not support listing collection facts")
+
+// ListFactsOptions pages ListFactsPage.
+type ListFactsOptions struct {
+	// Cursor continues from a previous page's NextCursor. Empty starts
+	// at the beginning.
+	Cursor string
+	// Limit caps the page size. Zero uses the server default.
+	Limit int
+}
+
+// FactPage is one page of a collection's facts.
+type FactPage struct {
+	Facts []Fact `json:"facts"`
+	// NextCursor is empty on the last page.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// ListFactsPage returns one page of the facts stored in the collection
+// with the given code, in a stable server-defined order.
+//
+// It uses GET /v1/orgs/{org}/collections/{id}/facts. Servers without
+// that endpoint answer 404 or 405, which is reported as an error
+// wrapping both ErrFactListingUnsupported and the *APIError.
+func (c *Client) ListFactsPage(ctx context.Context, orgID, code string, opts ListFactsOptions) (*FactPage, error) {
+	if strings.TrimSpace(orgID) == "" {
+		return nil, fmt.Errorf("org id is required")
+	}
+
+	query := url.Values{}
+	if opts.Cursor != "" {
+		query.Set("cursor", opts.Cursor)
+	}
+	if opts.Limit > 0 {
+		query.Set("limit", strconv.Itoa(opts.Limit))
+	}
+
+	var page *FactPage
+	err := c.withCollectionID(ctx, orgID, code, func(id string) error {
+		endpoint := fmt.Sprintf("/v1/orgs/%s/collections/%s/facts",
+			url.PathEscape(orgID), url.PathEscape(id))
+		if len(query) > 0 {
+			endpoint += "?" + query.Encode()
+		}
+		respBody, err := c.get(ctx, endpoint)
+		if err != nil {
+			return err
+		}
+
+		var resp APIResponse[FactPage]
+		if err := json.Unmarshal(respBody, &resp); err != nil {
+			return fmt.Errorf("failed to parse list facts response: %w", err)
+		}
+		page = &resp.Result
+		return nil
+	})
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusMethodNotAllowed) {
+		// The collection ID was resolved, so a 404 means the endpoint is
+		// missing rather than the collection.
+		err = fmt.Errorf("%w: %w", ErrFactListingUnsupported, err)
+	}
+	if err != nil {
+		c.logger.Error("Gomind ListFacts failed", "error", err, "orgID", orgID, "code", code)
+		return nil, err
+	}
+	return page, nil
+}
+
+// AllFacts walks every fact in the collection with the given code,
+// fetching each page only when the previous one has been consumed. An
+// error is yielded once and ends the sequence.
+func (c *Client) AllFacts(ctx context.Context, orgID, code string, opts ListFactsOptions) iter.Seq2[Fact, error] {
+	return func(yield func(Fact, error) bool) {
+		seen := make(map[string]bool)
+		for {
+			page, err := c.ListFactsPage(ctx, orgID, code, opts)
+			if err != nil {
+				yield(Fact{}, err)
+				return
+			}
+			for _, fact := range page.Facts {
+				if !yield(fact, nil) {
+					return
+				}
+			}
+			if page.NextCursor == "" {
+				return
+			}
+			if seen[page.NextCursor] {
+				yield(Fact{}, fmt.Errorf("list facts: cursor %q repeated", page.NextCursor))
+				return
+			}
+			seen[page.NextCursor] = true
+			opts.Cursor = page.NextCursor
+		}
+	}
+}
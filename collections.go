@@ -100,6 +100,7 @@ func (c *Client) CreateCollection(ctx context.Context, orgID, code, name, descri
 	if err := json.Unmarshal(respBody, &resp); err != nil {
 		return nil, fmt.Errorf("failed to parse create collection response: %w", err)
 	}
+	c.collectionIDs.put(orgID, resp.Result)
 	return &resp.Result, nil
 }
 
@@ -160,7 +161,9 @@ func (c *Client) UpdateCollection(ctx context.Context, orgID, id string, name, d
 
 // DeleteCollection hard-deletes a collection and all of its facts and
 // entities. The returned summary reports the totals removed from the
-// knowledge graph.
+// knowledge graph. Operational scripts should prefer
+// SafeDeleteCollection, which adds a dry run, a confirmation guard and
+// an optional backup.
 func (c *Client) DeleteCollection(ctx context.Context, orgID, id string) (*DeleteSummary, error) {
 	if strings.TrimSpace(orgID) == "" {
 		return nil, fmt.Errorf("org id is required")
@@ -181,13 +184,15 @@ func (c *Client) DeleteCollection(ctx context.Context, orgID, id string) (*Delet
 	if err := json.Unmarshal(respBody, &resp); err != nil {
 		return nil, fmt.Errorf("failed to parse delete collection response: %w", err)
 	}
+	c.collectionIDs.forget(orgID, "", id)
 	return &resp.Result, nil
 }
 
 // MoveFactsToCollection moves the given facts into the target collection.
 // The target collection ID is the *internal* collection ID, not the code.
 // Fails with 409 if any of the facts' referenced entities are shared with
-// facts outside the move set (v1 rejects rather than clones).
+// facts outside the move set (v1 rejects rather than clones); PlanMove
+// works out a move set that avoids the conflict.
 func (c *Client) MoveFactsToCollection(ctx context.Context, orgID, targetID string, factIDs []string) (*MoveSummary, error) {
 	if strings.TrimSpace(orgID) == "" {
 		return nil, fmt.Errorf("org id is required")
@@ -0,0 +1,200 @@
+package gomind
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// ErrCollectionNotFound is returned when no collection has the requested
+// code.
+var ErrCollectionNotFound = errors.New("collection not found")
+
+// collectionIDCache maps (org, code) to internal collection IDs. Codes
+// are immutable, so an entry only goes stale when its collection is
+// deleted, which callers detect as a 404.
+type collectionIDCache struct {
+	mu  sync.RWMutex
+	ids map[collectionKey]string
+}
+
+type collectionKey struct {
+	orgID string
+	code  string
+}
+
+func (m *collectionIDCache) get(orgID, code string) (string, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	id, ok := m.ids[collectionKey{orgID, code}]
+	return id, ok
+}
+
+func (m *collectionIDCache) put(orgID string, cols ...Collection) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.ids == nil {
+		m.ids = make(map[collectionKey]string)
+	}
+	for _, col := range cols {
+		m.ids[collectionKey{orgID, col.Code}] = col.ID
+	}
+}
+
+// forget drops the entry for code, or every entry pointing at id when
+// code is empty.
+func (m *collectionIDCache) forget(orgID, code, id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key, cached := range m.ids {
+		if key.orgID == orgID && (key.code == code || code == "" && cached == id) {
+			delete(m.ids, key)
+		}
+	}
+}
+
+// ResolveCollectionID returns the internal ID of the collection with the
+// given code. Results are cached per client, so repeated lookups cost no
+// requests; a miss lists the collections matching the code as a prefix
+// and caches them.
+func (c *Client) ResolveCollectionID(ctx context.Context, orgID, code string) (string, error) {
+	if canonicalCollection(code) == "" {
+		return "", fmt.Errorf("the default bucket has no collection id")
+	}
+	if id, ok := c.collectionIDs.get(orgID, code); ok {
+		return id, nil
+	}
+	return c.lookupCollectionID(ctx, orgID, code)
+}
+
+// lookupCollectionID resolves code from a fresh listing, bypassing the
+// cache. Every collection seen on the way is cached.
+func (c *Client) lookupCollectionID(ctx context.Context, orgID, code string) (string, error) {
+	var id string
+	for col, err := range c.AllCollections(ctx, orgID, ListCollectionsOptions{CodePrefix: code}) {
+		if err != nil {
+			return "", err
+		}
+		c.collectionIDs.put(orgID, col)
+		if col.Code == code {
+			id = col.ID
+		}
+	}
+	if id == "" {
+		return "", fmt.Errorf("%w: %q", ErrCollectionNotFound, code)
+	}
+	return id, nil
+}
+
+// withCollectionID resolves code and calls fn with its ID. If the cached
+// ID turns out to be stale (404), the cache entry is dropped and fn is
+// retried once with a freshly resolved ID.
+func (c *Client) withCollectionID(ctx context.Context, orgID, code string, fn func(id string) error) error {
+	_, cached := c.collectionIDs.get(orgID, code)
+	id, err := c.ResolveCollectionID(ctx, orgID, code)
+	if err != nil {
+		return err
+	}
+
+	err = fn(id)
+	var apiErr *APIError
+	if !cached || !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		return err
+	}
+
+	c.collectionIDs.forget(orgID, code, "")
+	id, err = c.lookupCollectionID(ctx, orgID, code)
+	if err != nil {
+		return err
+	}
+	return fn(id)
+}
+
+// GetCollectionByCode fetches a single collection by its code, with
+// FactCount populated. It returns an error wrapping ErrCollectionNotFound
+// when no collection has that code.
+func (c *Client) GetCollectionByCode(ctx context.Context, orgID, code string) (*Collection, error) {
+	var col *Collection
+	err := c.withCollectionID(ctx, orgID, code, func(id string) error {
+		var err error
+		col, err = c.GetCollection(ctx, orgID, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return col, nil
+}
+
+// EnsureCollection returns the collection with the given code, creating
+// it with name and description if it does not exist. An existing
+// collection is returned unchanged. Concurrent callers racing to create
+// the same code all receive the collection that won.
+func (c *Client) EnsureCollection(ctx context.Context, orgID, code, name, description string) (*Collection, error) {
+	col, err := c.GetCollectionByCode(ctx, orgID, code)
+	if err == nil || !errors.Is(err, ErrCollectionNotFound) {
+		return col, err
+	}
+
+	col, err = c.CreateCollection(ctx, orgID, code, name, description)
+	if err == nil {
+		c.logger.Info("Gomind EnsureCollection created", "orgID", orgID, "code", code)
+		return col, nil
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusConflict {
+		return nil, err
+	}
+	// Another caller created it first.
+	id, err := c.lookupCollectionID(ctx, orgID, code)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetCollection(ctx, orgID, id)
+}
+
+// UpdateCollectionByCode is UpdateCollection addressed by code.
+func (c *Client) UpdateCollectionByCode(ctx context.Context, orgID, code string, name, description *string) (*Collection, error) {
+	var col *Collection
+	err := c.withCollectionID(ctx, orgID, code, func(id string) error {
+		var err error
+		col, err = c.UpdateCollection(ctx, orgID, id, name, description)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return col, nil
+}
+
+// DeleteCollectionByCode is DeleteCollection addressed by code.
+func (c *Client) DeleteCollectionByCode(ctx context.Context, orgID, code string) (*DeleteSummary, error) {
+	var summary *DeleteSummary
+	err := c.withCollectionID(ctx, orgID, code, func(id string) error {
+		var err error
+		summary, err = c.DeleteCollection(ctx, orgID, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// MoveFactsToCollectionByCode is MoveFactsToCollection with the target
+// addressed by code.
+func (c *Client) MoveFactsToCollectionByCode(ctx context.Context, orgID, targetCode string, factIDs []string) (*MoveSummary, error) {
+	var summary *MoveSummary
+	err := c.withCollectionID(ctx, orgID, targetCode, func(id string) error {
+		var err error
+		summary, err = c.MoveFactsToCollection(ctx, orgID, id, factIDs)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
@@ -0,0 +1,184 @@
+package gomind
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeCollections is an in-memory collections API for org "org1".
+type fakeCollections struct {
+	mu    sync.Mutex
+	cols  map[string]Collection // by ID
+	lists int
+	// raceOnCreate makes the next create lose a race: the collection is
+	// stored under another ID and 409 is returned.
+	raceOnCreate bool
+}
+
+func (f *fakeCollections) handler(t *testing.T) http.Handler {
+	const prefix = "/v1/orgs/org1/collections/"
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		id := strings.TrimPrefix(r.URL.Path, prefix)
+		write := func(v any) {
+			json.NewEncoder(w).Encode(map[string]any{"status": "ok", "result": v})
+		}
+		switch {
+		case r.Method == http.MethodGet && id == "":
+			f.lists++
+			var out []Collection
+			for _, col := range f.cols {
+				out = append(out, col)
+			}
+			write(out)
+		case r.Method == http.MethodGet:
+			col, ok := f.cols[id]
+			if !ok {
+				http.Error(w, `{"error":"not found"}`, http.StatusNotFound)
+				return
+			}
+			n := 3
+			col.FactCount = &n
+			write(col)
+		case r.Method == http.MethodPost && id == "":
+			var body createCollectionRequest
+			json.NewDecoder(r.Body).Decode(&body)
+			if f.raceOnCreate {
+				f.raceOnCreate = false
+				f.cols["col-winner"] = Collection{ID: "col-winner", Code: body.Code, Name: "Winner"}
+				http.Error(w, `{"error":"code already exists"}`, http.StatusConflict)
+				return
+			}
+			col := Collection{ID: "col-" + body.Code, Code: body.Code, Name: body.Name}
+			f.cols[col.ID] = col
+			write(col)
+		case r.Method == http.MethodDelete:
+			delete(f.cols, id)
+			write(DeleteSummary{})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.Error(w, "unexpected", http.StatusBadRequest)
+		}
+	})
+}
+
+func newFakeCollectionsClient(t *testing.T, cols ...Collection) (*Client, *fakeCollections) {
+	t.Helper()
+	f := &fakeCollections{cols: make(map[string]Collection)}
+	for _, col := range cols {
+		f.cols[col.ID] = col
+	}
+	srv := httptest.NewServer(f.handler(t))
+	t.Cleanup(srv.Close)
+
+	client, err := NewClient("test-key", WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client, f
+}
+
+func TestGetCollectionByCode(t *testing.T) {
+	client, f := newFakeCollectionsClient(t,
+		Collection{ID: "c1", Code: "crm"},
+		Collection{ID: "c2", Code: "support"},
+	)
+	ctx := context.Background()
+
+	col, err := client.GetCollectionByCode(ctx, "org1", "crm")
+	if err != nil {
+		t.Fatalf("GetCollectionByCode: %v", err)
+	}
+	if col.ID != "c1" || col.FactCount == nil || *col.FactCount != 3 {
+		t.Errorf("unexpected collection %+v", col)
+	}
+	if _, err := client.GetCollectionByCode(ctx, "org1", "crm"); err != nil {
+		t.Fatalf("GetCollectionByCode: %v", err)
+	}
+	if f.lists != 1 {
+		t.Errorf("expected resolved IDs to be cached, listed %d times", f.lists)
+	}
+
+	if _, err := client.GetCollectionByCode(ctx, "org1", "missing"); !errors.Is(err, ErrCollectionNotFound) {
+		t.Errorf("expected ErrCollectionNotFound, got %v", err)
+	}
+	if _, err := client.ResolveCollectionID(ctx, "org1", "default"); err == nil {
+		t.Error("expected the default bucket to have no id")
+	}
+}
+
+// TestGetCollectionByCodeStaleCache verifies a collection deleted and
+// recreated behind the client's back is found under its new ID.
+func TestGetCollectionByCodeStaleCache(t *testing.T) {
+	client, f := newFakeCollectionsClient(t, Collection{ID: "c1", Code: "crm"})
+	ctx := context.Background()
+
+	if _, err := client.ResolveCollectionID(ctx, "org1", "crm"); err != nil {
+		t.Fatalf("ResolveCollectionID: %v", err)
+	}
+	f.mu.Lock()
+	delete(f.cols, "c1")
+	f.cols["c9"] = Collection{ID: "c9", Code: "crm"}
+	f.mu.Unlock()
+
+	col, err := client.GetCollectionByCode(ctx, "org1", "crm")
+	if err != nil {
+		t.Fatalf("GetCollectionByCode: %v", err)
+	}
+	if col.ID != "c9" {
+		t.Errorf("expected refreshed id c9, got %s", col.ID)
+	}
+}
+
+func TestEnsureCollection(t *testing.T) {
+	client, f := newFakeCollectionsClient(t, Collection{ID: "c1", Code: "crm", Name: "CRM"})
+	ctx := context.Background()
+
+	col, err := client.EnsureCollection(ctx, "org1", "crm", "Other name", "")
+	if err != nil {
+		t.Fatalf("EnsureCollection existing: %v", err)
+	}
+	if col.ID != "c1" || col.Name != "CRM" {
+		t.Errorf("expected existing collection unchanged, got %+v", col)
+	}
+
+	col, err = client.EnsureCollection(ctx, "org1", "billing", "Billing", "")
+	if err != nil {
+		t.Fatalf("EnsureCollection new: %v", err)
+	}
+	if col.ID != "col-billing" {
+		t.Errorf("unexpected created collection %+v", col)
+	}
+
+	f.raceOnCreate = true
+	col, err = client.EnsureCollection(ctx, "org1", "ops", "Ops", "")
+	if err != nil {
+		t.Fatalf("EnsureCollection race: %v", err)
+	}
+	if col.ID != "col-winner" {
+		t.Errorf("expected the winning collection after a 409, got %+v", col)
+	}
+}
+
+func TestDeleteCollectionByCodeInvalidatesCache(t *testing.T) {
+	client, _ := newFakeCollectionsClient(t, Collection{ID: "c1", Code: "crm"})
+	ctx := context.Background()
+
+	if _, err := client.DeleteCollectionByCode(ctx, "org1", "crm"); err != nil {
+		t.Fatalf("DeleteCollectionByCode: %v", err)
+	}
+	if _, ok := client.collectionIDs.get("org1", "crm"); ok {
+		t.Error("expected deleted collection to be dropped from the cache")
+	}
+	if _, err := client.GetCollectionByCode(ctx, "org1", "crm"); !errors.Is(err, ErrCollectionNotFound) {
+		t.Errorf("expected ErrCollectionNotFound after delete, got %v", err)
+	}
+}
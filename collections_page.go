@@ -0,0 +1,132 @@
+package gomind
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ListCollectionsOptions filters and pages ListCollectionsPage.
+type ListCollectionsOptions struct {
+	// Cursor continues from a previous page's NextCursor. Empty starts
+	// at the beginning.
+	Cursor string
+	// Limit caps the page size. Zero uses the server default.
+	Limit int
+	// NamePrefix and CodePrefix keep only collections whose name or code
+	// starts with the given prefix.
+	NamePrefix string
+	CodePrefix string
+	// IncludeFactCount populates Collection.FactCount.
+	IncludeFactCount bool
+}
+
+// CollectionPage is one page of collections.
+type CollectionPage struct {
+	Collections []Collection `json:"collections"`
+	// NextCursor is empty on the last page.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// ListCollectionsPage returns one page of an org's collections.
+func (c *Client) ListCollectionsPage(ctx context.Context, orgID string, opts ListCollectionsOptions) (*CollectionPage, error) {
+	if strings.TrimSpace(orgID) == "" {
+		return nil, fmt.Errorf("org id is required")
+	}
+
+	query := url.Values{}
+	if opts.Cursor != "" {
+		query.Set("cursor", opts.Cursor)
+	}
+	if opts.Limit > 0 {
+		query.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.NamePrefix != "" {
+		query.Set("name_prefix", opts.NamePrefix)
+	}
+	if opts.CodePrefix != "" {
+		query.Set("code_prefix", opts.CodePrefix)
+	}
+	if opts.IncludeFactCount {
+		query.Set("include", "fact_count")
+	}
+
+	endpoint := fmt.Sprintf("/v1/orgs/%s/collections/", url.PathEscape(orgID))
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+	respBody, err := c.get(ctx, endpoint)
+	if err != nil {
+		c.logger.Error("Gomind ListCollectionsPage failed", "error", err, "orgID", orgID)
+		return nil, err
+	}
+
+	var resp APIResponse[json.RawMessage]
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse list collections response: %w", err)
+	}
+	page, err := parseCollectionPage(resp.Result, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse list collections response: %w", err)
+	}
+	return page, nil
+}
+
+// parseCollectionPage decodes a paged result. Servers without paging
+// return a bare array of every collection; that is treated as a single
+// last page, with the prefix filters applied client-side.
+func parseCollectionPage(raw json.RawMessage, opts ListCollectionsOptions) (*CollectionPage, error) {
+	if trimmed := strings.TrimSpace(string(raw)); !strings.HasPrefix(trimmed, "[") && trimmed != "null" {
+		var page CollectionPage
+		if err := json.Unmarshal(raw, &page); err != nil {
+			return nil, err
+		}
+		return &page, nil
+	}
+
+	var all []Collection
+	if err := json.Unmarshal(raw, &all); err != nil {
+		return nil, err
+	}
+	page := &CollectionPage{}
+	for _, col := range all {
+		if strings.HasPrefix(col.Name, opts.NamePrefix) && strings.HasPrefix(col.Code, opts.CodePrefix) {
+			page.Collections = append(page.Collections, col)
+		}
+	}
+	return page, nil
+}
+
+// AllCollections walks every page matching opts, fetching each page only
+// when the previous one has been consumed. opts.Cursor sets the starting
+// point. An error is yielded once and ends the sequence.
+func (c *Client) AllCollections(ctx context.Context, orgID string, opts ListCollectionsOptions) iter.Seq2[Collection, error] {
+	return func(yield func(Collection, error) bool) {
+		seen := make(map[string]bool)
+		for {
+			page, err := c.ListCollectionsPage(ctx, orgID, opts)
+			if err != nil {
+				yield(Collection{}, err)
+				return
+			}
+			for _, col := range page.Collections {
+				if !yield(col, nil) {
+					return
+				}
+			}
+			if page.NextCursor == "" {
+				return
+			}
+			if seen[page.NextCursor] {
+				yield(Collection{}, fmt.Errorf("list collections: cursor %q repeated", page.NextCursor))
+				return
+			}
+			seen[page.NextCursor] = true
+			opts.Cursor = page.NextCursor
+		}
+	}
+}
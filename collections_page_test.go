@@ -0,0 +1,122 @@
+package gomind
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// newPagedCollectionsServer serves n collections named col-00.. in pages
+// honouring cursor, limit, code_prefix and include.
+func newPagedCollectionsServer(t *testing.T, n int, requests *[]string) *Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.URL.RawQuery)
+		q := r.URL.Query()
+		start, _ := strconv.Atoi(q.Get("cursor"))
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		if limit == 0 {
+			limit = 2
+		}
+
+		page := CollectionPage{Collections: []Collection{}}
+		i := start
+		for ; i < n && len(page.Collections) < limit; i++ {
+			col := Collection{ID: fmt.Sprintf("id-%02d", i), Code: fmt.Sprintf("col-%02d", i)}
+			if q.Get("include") == "fact_count" {
+				count := i
+				col.FactCount = &count
+			}
+			page.Collections = append(page.Collections, col)
+		}
+		if i < n {
+			page.NextCursor = strconv.Itoa(i)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"status": "ok", "result": page})
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := NewClient("test-key", WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
+
+func TestListCollectionsPage(t *testing.T) {
+	var requests []string
+	client := newPagedCollectionsServer(t, 5, &requests)
+
+	page, err := client.ListCollectionsPage(context.Background(), "org1", ListCollectionsOptions{
+		Cursor:           "1",
+		Limit:            3,
+		CodePrefix:       "col-",
+		IncludeFactCount: true,
+	})
+	if err != nil {
+		t.Fatalf("ListCollectionsPage: %v", err)
+	}
+	if len(page.Collections) != 3 || page.Collections[0].Code != "col-01" || page.NextCursor != "4" {
+		t.Errorf("unexpected page %+v", page)
+	}
+	if page.Collections[0].FactCount == nil || *page.Collections[0].FactCount != 1 {
+		t.Errorf("expected fact counts to be included")
+	}
+	if want := "code_prefix=col-&cursor=1&include=fact_count&limit=3"; requests[0] != want {
+		t.Errorf("unexpected query %q, want %q", requests[0], want)
+	}
+}
+
+func TestAllCollections(t *testing.T) {
+	var requests []string
+	client := newPagedCollectionsServer(t, 5, &requests)
+
+	var codes []string
+	for col, err := range client.AllCollections(context.Background(), "org1", ListCollectionsOptions{}) {
+		if err != nil {
+			t.Fatalf("AllCollections: %v", err)
+		}
+		codes = append(codes, col.Code)
+	}
+	if len(codes) != 5 || codes[4] != "col-04" {
+		t.Errorf("unexpected collections %v", codes)
+	}
+	if len(requests) != 3 {
+		t.Errorf("expected 3 page requests, got %d", len(requests))
+	}
+
+	// Pages are fetched lazily.
+	requests = nil
+	for range client.AllCollections(context.Background(), "org1", ListCollectionsOptions{}) {
+		break
+	}
+	if len(requests) != 1 {
+		t.Errorf("expected a single request when stopping early, got %d", len(requests))
+	}
+}
+
+// TestListCollectionsPageLegacy verifies a server returning a bare array
+// is treated as a single, client-side filtered page.
+func TestListCollectionsPageLegacy(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"ok","result":[{"id":"1","code":"crm","name":"CRM"},{"id":"2","code":"support","name":"Support"}]}`))
+	}))
+	defer srv.Close()
+
+	client, err := NewClient("test-key", WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	page, err := client.ListCollectionsPage(context.Background(), "org1", ListCollectionsOptions{NamePrefix: "Sup"})
+	if err != nil {
+		t.Fatalf("ListCollectionsPage: %v", err)
+	}
+	if len(page.Collections) != 1 || page.Collections[0].Code != "support" || page.NextCursor != "" {
+		t.Errorf("unexpected page %+v", page)
+	}
+}
@@ -0,0 +1,411 @@
+// Package csvimport bulk-loads facts from CSV or TSV files, such as
+// spreadsheets saved as CSV.
+//
+// In the default long format every row is one fact and Columns names
+// the header cells holding subject, predicate, object and, optionally,
+// context and collection:
+//
+//	subject,predicate,object
+//	John,works_at,Acme
+//
+// In wide format (Options.Wide) every row is a subject and every other
+// column header is a predicate; each non-empty cell becomes a fact:
+//
+//	name,works_at,age
+//	John,Acme,42
+package csvimport
+
+import (
+	"cmp"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode"
+
+	gomind "github.com/ingate/gomind-go-sdk"
+)
+
+// ErrTooManyErrors is returned when more rows fail validation than
+// Options.MaxErrors allows.
+var ErrTooManyErrors = errors.New("too many invalid rows")
+
+// Columns names the header cells of a long-format file. Matching is
+// case-insensitive. Subject, Predicate and Object default to "subject",
+// "predicate" and "object"; Context and Collection are optional and
+// default to "context" and "collection" when such a column exists.
+type Columns struct {
+	Subject    string
+	Predicate  string
+	Object     string
+	Context    string
+	Collection string
+}
+
+// Options configures Import.
+type Options struct {
+	// Comma is the field delimiter. Defaults to ','; use '\t' for TSV.
+	Comma rune
+
+	// Columns maps long-format columns.
+	Columns Columns
+
+	// Wide selects wide format. SubjectColumn names the subject column
+	// and defaults to the first one. Context and collection columns
+	// named in Columns apply to every fact of the row and must exist
+	// when named, and IgnoreColumns are skipped.
+	Wide          bool
+	SubjectColumn string
+	IgnoreColumns []string
+
+	// Collection is the collection for rows without a collection cell.
+	// See RememberRequest.Collection for the *string semantics.
+	Collection *string
+
+	// Source is passed through to every RememberManyRequest.
+	Source string
+
+	// BatchSize is the number of facts per remember_many request.
+	// Defaults to gomind.DefaultImportBatchSize.
+	BatchSize int
+
+	// DryRun validates the file and counts facts and batches without
+	// writing anything.
+	DryRun bool
+
+	// MaxErrors aborts the import with ErrTooManyErrors once more rows
+	// than this fail validation. Zero means no limit.
+	MaxErrors int
+
+	// Progress, when set, is called after every batch.
+	Progress func(Summary)
+}
+
+// RowError describes an invalid row.
+type RowError struct {
+	Line   int
+	Column string
+	Msg    string
+}
+
+func (e *RowError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("line %d: %s", e.Line, e.Msg)
+	}
+	return fmt.Sprintf("line %d: column %q: %s", e.Line, e.Column, e.Msg)
+}
+
+// Summary reports the outcome of Import.
+type Summary struct {
+	// Rows counts data rows read, excluding the header.
+	Rows int
+	// Facts counts valid facts found.
+	Facts int
+	// Imported counts facts written. It stays zero on a dry run.
+	Imported int
+	// Batches counts remember_many requests sent, or that would be sent
+	// on a dry run.
+	Batches int
+	// Invalid lists the rows that failed validation and were skipped.
+	Invalid []*RowError
+}
+
+// Import reads a CSV file and stores its facts in batches through
+// RememberManyWithOptions. Invalid rows are skipped and reported in
+// Summary.Invalid; a row with any invalid cell is skipped as a whole.
+// Rows are streamed, so memory use is bounded by the batch size.
+// Batches already written stay written if the import fails part way.
+func Import(ctx context.Context, c *gomind.Client, r io.Reader, opts Options) (*Summary, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = gomind.DefaultImportBatchSize
+	}
+
+	cr := csv.NewReader(r)
+	cr.Comma = cmp.Or(opts.Comma, ',')
+	// Trimming would swallow empty cells of tab-separated files.
+	cr.TrimLeadingSpace = !unicode.IsSpace(cr.Comma)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("csv file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+	if len(header) > 0 {
+		// Spreadsheet exports often start with a byte order mark.
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+
+	var layout rowLayout
+	if opts.Wide {
+		layout, err = newWideLayout(header, opts)
+	} else {
+		layout, err = newLongLayout(header, opts.Columns)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	imp := &importer{client: c, ctx: ctx, opts: opts}
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return &imp.summary, fmt.Errorf("failed to read csv: %w", err)
+			}
+			if err := imp.invalid(&RowError{Line: parseErr.Line, Msg: parseErr.Err.Error()}); err != nil {
+				return &imp.summary, err
+			}
+			continue
+		}
+		line, _ := cr.FieldPos(0)
+		if isBlank(record) {
+			continue
+		}
+		imp.summary.Rows++
+
+		facts, rowErr := layout.facts(record, line)
+		if rowErr != nil {
+			if err := imp.invalid(rowErr); err != nil {
+				return &imp.summary, err
+			}
+			continue
+		}
+		for _, f := range facts {
+			if err := imp.add(f); err != nil {
+				return &imp.summary, err
+			}
+		}
+	}
+	if err := imp.flush(); err != nil {
+		return &imp.summary, err
+	}
+	return &imp.summary, nil
+}
+
+// rowFact is a fact with the collection from its row.
+type rowFact struct {
+	gomind.RememberRequest
+	collection string
+}
+
+// rowLayout turns a record into facts.
+type rowLayout interface {
+	facts(record []string, line int) ([]rowFact, *RowError)
+}
+
+// columnIndex returns the index of the header cell matching name, or -1.
+func columnIndex(header []string, name string) int {
+	return slices.IndexFunc(header, func(h string) bool {
+		return strings.EqualFold(strings.TrimSpace(h), name)
+	})
+}
+
+func cell(record []string, i int) string {
+	if i < 0 || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+func isBlank(record []string) bool {
+	return !slices.ContainsFunc(record, func(s string) bool { return strings.TrimSpace(s) != "" })
+}
+
+type longLayout struct {
+	header                                          []string
+	subject, predicate, object, context, collection int
+}
+
+func newLongLayout(header []string, cols Columns) (*longLayout, error) {
+	l := &longLayout{header: header}
+	for _, col := range []struct {
+		index    *int
+		name     string
+		required bool
+	}{
+		{&l.subject, cmp.Or(cols.Subject, "subject"), true},
+		{&l.predicate, cmp.Or(cols.Predicate, "predicate"), true},
+		{&l.object, cmp.Or(cols.Object, "object"), true},
+		{&l.context, cmp.Or(cols.Context, "context"), cols.Context != ""},
+		{&l.collection, cmp.Or(cols.Collection, "collection"), cols.Collection != ""},
+	} {
+		*col.index = columnIndex(header, col.name)
+		if *col.index < 0 && col.required {
+			return nil, fmt.Errorf("csv header has no %q column", col.name)
+		}
+	}
+	return l, nil
+}
+
+func (l *longLayout) facts(record []string, line int) ([]rowFact, *RowError) {
+	f := rowFact{
+		RememberRequest: gomind.RememberRequest{
+			Subject:   cell(record, l.subject),
+			Predicate: cell(record, l.predicate),
+			Object:    cell(record, l.object),
+			Context:   cell(record, l.context),
+		},
+		collection: cell(record, l.collection),
+	}
+	for _, required := range []struct {
+		index int
+		value string
+	}{
+		{l.subject, f.Subject},
+		{l.predicate, f.Predicate},
+		{l.object, f.Object},
+	} {
+		if required.value == "" {
+			return nil, &RowError{Line: line, Column: l.header[required.index], Msg: "value is required"}
+		}
+	}
+	return []rowFact{f}, nil
+}
+
+type wideLayout struct {
+	header              []string
+	subject             int
+	context, collection int
+	predicates          []int
+}
+
+func newWideLayout(header []string, opts Options) (*wideLayout, error) {
+	l := &wideLayout{header: header}
+	if opts.SubjectColumn != "" {
+		if l.subject = columnIndex(header, opts.SubjectColumn); l.subject < 0 {
+			return nil, fmt.Errorf("csv header has no %q column", opts.SubjectColumn)
+		}
+	}
+	for _, col := range []struct {
+		index *int
+		name  string
+	}{
+		{&l.context, opts.Columns.Context},
+		{&l.collection, opts.Columns.Collection},
+	} {
+		*col.index = -1
+		if col.name == "" {
+			continue
+		}
+		if *col.index = columnIndex(header, col.name); *col.index < 0 {
+			return nil, fmt.Errorf("csv header has no %q column", col.name)
+		}
+	}
+
+	for i, h := range header {
+		h = strings.TrimSpace(h)
+		ignored := slices.ContainsFunc(opts.IgnoreColumns, func(name string) bool { return strings.EqualFold(name, h) })
+		if i == l.subject || i == l.context || i == l.collection || ignored {
+			continue
+		}
+		if h == "" {
+			return nil, fmt.Errorf("csv header column %d has no predicate name", i+1)
+		}
+		l.predicates = append(l.predicates, i)
+	}
+	if len(l.predicates) == 0 {
+		return nil, errors.New("csv header has no predicate columns")
+	}
+	return l, nil
+}
+
+func (l *wideLayout) facts(record []string, line int) ([]rowFact, *RowError) {
+	subject := cell(record, l.subject)
+	if subject == "" {
+		return nil, &RowError{Line: line, Column: l.header[l.subject], Msg: "subject is required"}
+	}
+	if len(record) > len(l.header) {
+		return nil, &RowError{Line: line, Msg: fmt.Sprintf("row has %d cells but the header has %d", len(record), len(l.header))}
+	}
+
+	var facts []rowFact
+	for _, i := range l.predicates {
+		object := cell(record, i)
+		if object == "" {
+			continue
+		}
+		facts = append(facts, rowFact{
+			RememberRequest: gomind.RememberRequest{
+				Subject:   subject,
+				Predicate: strings.TrimSpace(l.header[i]),
+				Object:    object,
+				Context:   cell(record, l.context),
+			},
+			collection: cell(record, l.collection),
+		})
+	}
+	return facts, nil
+}
+
+// importer batches the facts of one Import call.
+type importer struct {
+	client *gomind.Client
+	ctx    context.Context
+	opts   Options
+
+	batch      []gomind.RememberRequest
+	collection string
+	summary    Summary
+}
+
+func (imp *importer) invalid(rowErr *RowError) error {
+	imp.summary.Invalid = append(imp.summary.Invalid, rowErr)
+	if imp.opts.MaxErrors > 0 && len(imp.summary.Invalid) > imp.opts.MaxErrors {
+		return fmt.Errorf("%w: stopped at %v", ErrTooManyErrors, rowErr)
+	}
+	return nil
+}
+
+// add queues a fact, starting a new batch when the collection changes
+// since a remember_many request targets a single collection.
+func (imp *importer) add(f rowFact) error {
+	if len(imp.batch) > 0 && f.collection != imp.collection {
+		if err := imp.flush(); err != nil {
+			return err
+		}
+	}
+	imp.collection = f.collection
+	imp.batch = append(imp.batch, f.RememberRequest)
+	imp.summary.Facts++
+	if len(imp.batch) >= imp.opts.BatchSize {
+		return imp.flush()
+	}
+	return nil
+}
+
+func (imp *importer) flush() error {
+	if len(imp.batch) == 0 {
+		return nil
+	}
+	imp.summary.Batches++
+	if !imp.opts.DryRun {
+		collection := imp.opts.Collection
+		if imp.collection != "" {
+			collection = gomind.CollectionScope(imp.collection)
+		}
+		err := imp.client.RememberManyWithOptions(imp.ctx, gomind.RememberManyRequest{
+			Facts:      imp.batch,
+			Source:     imp.opts.Source,
+			Collection: collection,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to import batch %d: %w", imp.summary.Batches, err)
+		}
+		imp.summary.Imported += len(imp.batch)
+	}
+	imp.batch = nil
+	if imp.opts.Progress != nil {
+		imp.opts.Progress(imp.summary)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package csvimport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	gomind "github.com/ingate/gomind-go-sdk"
+)
+
+// newServer records every remember_many request.
+func newServer(t *testing.T, batches *[]gomind.RememberManyRequest) *gomind.Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/remember_many" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		var req gomind.RememberManyRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		*batches = append(*batches, req)
+		json.NewEncoder(w).Encode(map[string]any{"status": "ok", "result": map[string]any{}})
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := gomind.NewClient("test-key", gomind.WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
+
+func TestImportLong(t *testing.T) {
+	var batches []gomind.RememberManyRequest
+	client := newServer(t, &batches)
+
+	input := "\ufeffSubject,Predicate,Object,Context,Collection\n" +
+		"John,works_at,Acme,from CRM,crm\n" +
+		"Jane,works_at,Acme,,crm\n" +
+		"\n" +
+		"Bob,,Tea,,\n" +
+		"\"Smith, J\",likes,\"Tea, green\",,\n"
+
+	summary, err := Import(context.Background(), client, strings.NewReader(input), Options{Source: "sheet"})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if summary.Rows != 4 || summary.Facts != 3 || summary.Imported != 3 || summary.Batches != 2 {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+	if len(summary.Invalid) != 1 || summary.Invalid[0].Error() != `line 5: column "Predicate": value is required` {
+		t.Errorf("unexpected invalid rows %v", summary.Invalid)
+	}
+
+	// The collection change starts a new batch.
+	if len(batches) != 2 || *batches[0].Collection != "crm" || batches[1].Collection != nil {
+		t.Fatalf("unexpected batches %+v", batches)
+	}
+	if batches[0].Source != "sheet" || batches[0].Facts[0].Context != "from CRM" {
+		t.Errorf("unexpected first batch %+v", batches[0])
+	}
+	want := gomind.RememberRequest{Subject: "Smith, J", Predicate: "likes", Object: "Tea, green"}
+	if batches[1].Facts[0] != want {
+		t.Errorf("unexpected quoted fact %+v", batches[1].Facts[0])
+	}
+}
+
+func TestImportWideTSV(t *testing.T) {
+	var batches []gomind.RememberManyRequest
+	client := newServer(t, &batches)
+
+	input := "id\tname\tworks_at\tage\tnotes\n" +
+		"1\tJohn\tAcme\t42\tignore me\n" +
+		"2\tJane\t\t37\t\n" +
+		"3\t\tAcme\t50\t\n"
+
+	summary, err := Import(context.Background(), client, strings.NewReader(input), Options{
+		Comma:         '\t',
+		Wide:          true,
+		SubjectColumn: "name",
+		IgnoreColumns: []string{"id", "notes"},
+		BatchSize:     2,
+	})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if summary.Facts != 3 || summary.Batches != 2 || len(summary.Invalid) != 1 || summary.Invalid[0].Line != 4 {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+	var got []gomind.RememberRequest
+	for _, b := range batches {
+		got = append(got, b.Facts...)
+	}
+	want := []gomind.RememberRequest{
+		{Subject: "John", Predicate: "works_at", Object: "Acme"},
+		{Subject: "John", Predicate: "age", Object: "42"},
+		{Subject: "Jane", Predicate: "age", Object: "37"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected facts %+v", got)
+	}
+}
+
+func TestImportDryRun(t *testing.T) {
+	var batches []gomind.RememberManyRequest
+	client := newServer(t, &batches)
+
+	input := "s,p,o\nJohn,works_at,Acme\nJane,works_at,\n"
+	summary, err := Import(context.Background(), client, strings.NewReader(input), Options{
+		Columns: Columns{Subject: "s", Predicate: "p", Object: "o"},
+		DryRun:  true,
+	})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if len(batches) != 0 {
+		t.Fatal("dry run must not write")
+	}
+	if summary.Facts != 1 || summary.Batches != 1 || summary.Imported != 0 || len(summary.Invalid) != 1 {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+}
+
+func TestImportErrors(t *testing.T) {
+	var batches []gomind.RememberManyRequest
+	client := newServer(t, &batches)
+	ctx := context.Background()
+
+	if _, err := Import(ctx, client, strings.NewReader("subject,object\n"), Options{}); err == nil || !strings.Contains(err.Error(), `"predicate"`) {
+		t.Errorf("expected a missing column error, got %v", err)
+	}
+	_, err := Import(ctx, client, strings.NewReader("name,age\n"), Options{
+		Wide: true, SubjectColumn: "name", Columns: Columns{Context: "note"},
+	})
+	if err == nil || !strings.Contains(err.Error(), `no "note" column`) {
+		t.Errorf("expected a missing context column error in wide mode, got %v", err)
+	}
+
+	input := "subject,predicate,object\n,,\"x\" y\nJohn,,\n"
+	summary, err := Import(ctx, client, strings.NewReader(input), Options{MaxErrors: 1})
+	if !errors.Is(err, ErrTooManyErrors) || len(summary.Invalid) != 2 {
+		t.Errorf("expected ErrTooManyErrors after two bad rows, got %v, %+v", err, summary)
+	}
+	if summary.Invalid[0].Line != 2 {
+		t.Errorf("expected the parse error on line 2, got %v", summary.Invalid[0])
+	}
+}
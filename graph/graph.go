@@ -0,0 +1,353 @@
+// Package graph builds a local, in-memory knowledge graph from Gomind
+// facts so recalled neighbourhoods can be explored without further API
+// calls.
+//
+// Entities become nodes and facts with an Object become directed edges
+// from subject to object, labelled with the predicate. Facts carrying a
+// literal Value instead are stored as node attributes, and facts whose
+// predicate is a type predicate ("type", "is_a", "instance_of") set the
+// node's Type. Traversals treat edges as undirected, since a fact
+// connects both of its entities.
+package graph
+
+import (
+	"slices"
+
+	gomind "github.com/ingate/gomind-go-sdk"
+)
+
+// TypePredicates are the predicates that set Node.Type rather than
+// adding an edge.
+var TypePredicates = []string{"type", "is_a", "instance_of"}
+
+// Node is an entity of the graph.
+type Node struct {
+	ID string
+	// Type is the object of the entity's type fact, if any.
+	Type string
+	// Values holds literal values by predicate, in fact order.
+	Values map[string][]string
+}
+
+// Edge is a fact linking two entities.
+type Edge struct {
+	From      string
+	To        string
+	Predicate string
+	Fact      gomind.Fact
+}
+
+// Graph is a directed multigraph of entities. The zero value is not
+// usable; create graphs with New. A Graph is not safe for concurrent
+// modification.
+type Graph struct {
+	nodes map[string]*Node
+	order []string
+	out   map[string][]Edge
+	in    map[string][]Edge
+	facts []gomind.Fact
+	edges int
+}
+
+// New builds a graph from facts.
+func New(facts []gomind.Fact) *Graph {
+	g := &Graph{
+		nodes: make(map[string]*Node),
+		out:   make(map[string][]Edge),
+		in:    make(map[string][]Edge),
+	}
+	for _, f := range facts {
+		g.Add(f)
+	}
+	return g
+}
+
+// Add inserts a fact. Facts without a subject are ignored.
+func (g *Graph) Add(f gomind.Fact) {
+	if f.Subject == "" {
+		return
+	}
+	g.facts = append(g.facts, f)
+	subject := g.node(f.Subject)
+
+	switch {
+	case slices.Contains(TypePredicates, f.Predicate) && (f.Object != "" || f.Value != ""):
+		subject.Type = f.Object
+		if subject.Type == "" {
+			subject.Type = f.Value
+		}
+	case f.Object != "":
+		g.node(f.Object)
+		e := Edge{From: f.Subject, To: f.Object, Predicate: f.Predicate, Fact: f}
+		g.out[e.From] = append(g.out[e.From], e)
+		g.in[e.To] = append(g.in[e.To], e)
+		g.edges++
+	case f.Value != "":
+		if subject.Values == nil {
+			subject.Values = make(map[string][]string)
+		}
+		subject.Values[f.Predicate] = append(subject.Values[f.Predicate], f.Value)
+	}
+}
+
+func (g *Graph) node(id string) *Node {
+	n, ok := g.nodes[id]
+	if !ok {
+		n = &Node{ID: id}
+		g.nodes[id] = n
+		g.order = append(g.order, id)
+	}
+	return n
+}
+
+// Node returns the node with the given ID.
+func (g *Graph) Node(id string) (*Node, bool) {
+	n, ok := g.nodes[id]
+	return n, ok
+}
+
+// Nodes returns every node in order of first appearance.
+func (g *Graph) Nodes() []*Node {
+	nodes := make([]*Node, len(g.order))
+	for i, id := range g.order {
+		nodes[i] = g.nodes[id]
+	}
+	return nodes
+}
+
+// Edges returns every edge, grouped by source node in order of first
+// appearance.
+func (g *Graph) Edges() []Edge {
+	edges := make([]Edge, 0, g.edges)
+	for _, id := range g.order {
+		edges = append(edges, g.out[id]...)
+	}
+	return edges
+}
+
+// Facts returns the facts the graph was built from.
+func (g *Graph) Facts() []gomind.Fact {
+	return slices.Clone(g.facts)
+}
+
+// Len returns the number of nodes.
+func (g *Graph) Len() int {
+	return len(g.order)
+}
+
+// Out returns the edges leaving id.
+func (g *Graph) Out(id string) []Edge {
+	return slices.Clone(g.out[id])
+}
+
+// In returns the edges pointing at id.
+func (g *Graph) In(id string) []Edge {
+	return slices.Clone(g.in[id])
+}
+
+// Degree returns the number of edges touching id in either direction.
+func (g *Graph) Degree(id string) int {
+	return len(g.out[id]) + len(g.in[id])
+}
+
+// Neighbors returns the entities linked to id in either direction,
+// without duplicates, in edge order. Pass predicates to follow only
+// edges with those predicates.
+func (g *Graph) Neighbors(id string, predicates ...string) []string {
+	var out []string
+	seen := map[string]bool{id: true}
+	visit := func(other, predicate string) {
+		if seen[other] || len(predicates) > 0 && !slices.Contains(predicates, predicate) {
+			return
+		}
+		seen[other] = true
+		out = append(out, other)
+	}
+	for _, e := range g.out[id] {
+		visit(e.To, e.Predicate)
+	}
+	for _, e := range g.in[id] {
+		visit(e.From, e.Predicate)
+	}
+	return out
+}
+
+// edgesOf returns the edges touching id in either direction.
+func (g *Graph) edgesOf(id string) []Edge {
+	return append(slices.Clone(g.out[id]), g.in[id]...)
+}
+
+// Visit is a node reached by BFS.
+type Visit struct {
+	ID    string
+	Depth int
+}
+
+// BFS returns the nodes within depth hops of start, start included at
+// depth 0, in breadth-first order. A negative depth is unlimited.
+func (g *Graph) BFS(start string, depth int) []Visit {
+	if _, ok := g.nodes[start]; !ok {
+		return nil
+	}
+	visits := []Visit{{ID: start}}
+	seen := map[string]bool{start: true}
+	for i := 0; i < len(visits); i++ {
+		v := visits[i]
+		if depth >= 0 && v.Depth >= depth {
+			continue
+		}
+		for _, next := range g.Neighbors(v.ID) {
+			if !seen[next] {
+				seen[next] = true
+				visits = append(visits, Visit{ID: next, Depth: v.Depth + 1})
+			}
+		}
+	}
+	return visits
+}
+
+// ShortestPath returns the edges of a shortest path from one entity to
+// another, ignoring edge direction, and whether a path exists. A path
+// from a node to itself is empty.
+func (g *Graph) ShortestPath(from, to string) ([]Edge, bool) {
+	if _, ok := g.nodes[from]; !ok {
+		return nil, false
+	}
+	if _, ok := g.nodes[to]; !ok {
+		return nil, false
+	}
+
+	via := map[string]Edge{}
+	seen := map[string]bool{from: true}
+	queue := []string{from}
+	for len(queue) > 0 && !seen[to] {
+		id := queue[0]
+		queue = queue[1:]
+		for _, e := range g.edgesOf(id) {
+			next := e.To
+			if next == id {
+				next = e.From
+			}
+			if !seen[next] {
+				seen[next] = true
+				via[next] = e
+				queue = append(queue, next)
+			}
+		}
+	}
+	if !seen[to] {
+		return nil, false
+	}
+
+	var path []Edge
+	for id := to; id != from; {
+		e := via[id]
+		path = append(path, e)
+		if e.To == id {
+			id = e.From
+		} else {
+			id = e.To
+		}
+	}
+	slices.Reverse(path)
+	return path, true
+}
+
+// Components returns the connected components, ignoring edge direction,
+// largest first. Nodes within a component are in order of first
+// appearance.
+func (g *Graph) Components() [][]string {
+	seen := make(map[string]bool)
+	var components [][]string
+	for _, id := range g.order {
+		if seen[id] {
+			continue
+		}
+		var component []string
+		for _, v := range g.BFS(id, -1) {
+			seen[v.ID] = true
+			component = append(component, v.ID)
+		}
+		components = append(components, component)
+	}
+
+	rank := make(map[string]int, len(g.order))
+	for i, id := range g.order {
+		rank[id] = i
+	}
+	for _, component := range components {
+		slices.SortFunc(component, func(a, b string) int { return rank[a] - rank[b] })
+	}
+	slices.SortStableFunc(components, func(a, b []string) int { return len(b) - len(a) })
+	return components
+}
+
+// Stats summarises the degree distribution of a graph.
+type Stats struct {
+	Nodes      int
+	Edges      int
+	Components int
+	MinDegree  int
+	MaxDegree  int
+	MeanDegree float64
+	// Hubs lists the nodes with MaxDegree.
+	Hubs []string
+}
+
+// Stats returns degree statistics.
+func (g *Graph) Stats() Stats {
+	s := Stats{Nodes: len(g.order), Edges: g.edges, Components: len(g.Components())}
+	if s.Nodes == 0 {
+		return s
+	}
+	s.MinDegree = -1
+	total := 0
+	for _, id := range g.order {
+		d := g.Degree(id)
+		total += d
+		if s.MinDegree < 0 || d < s.MinDegree {
+			s.MinDegree = d
+		}
+		switch {
+		case d > s.MaxDegree:
+			s.MaxDegree = d
+			s.Hubs = []string{id}
+		case d == s.MaxDegree:
+			s.Hubs = append(s.Hubs, id)
+		}
+	}
+	s.MeanDegree = float64(total) / float64(s.Nodes)
+	return s
+}
+
+// Subgraph returns the graph induced by ids: those nodes with their
+// attributes and the edges between them.
+func (g *Graph) Subgraph(ids ...string) *Graph {
+	keep := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		keep[id] = true
+	}
+	sub := New(nil)
+	// Create the nodes first so they keep their relative order.
+	for _, id := range g.order {
+		if keep[id] {
+			sub.node(id)
+		}
+	}
+	for _, f := range g.facts {
+		if keep[f.Subject] && (f.Object == "" || keep[f.Object] || slices.Contains(TypePredicates, f.Predicate)) {
+			sub.Add(f)
+		}
+	}
+	return sub
+}
+
+// Neighborhood returns the subgraph within depth hops of id.
+func (g *Graph) Neighborhood(id string, depth int) *Graph {
+	visits := g.BFS(id, depth)
+	ids := make([]string, len(visits))
+	for i, v := range visits {
+		ids[i] = v.ID
+	}
+	return g.Subgraph(ids...)
+}
@@ -0,0 +1,112 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+
+	gomind "github.com/ingate/gomind-go-sdk"
+)
+
+var testFacts = []gomind.Fact{
+	{Subject: "John", Predicate: "works_at", Object: "Acme"},
+	{Subject: "Jane", Predicate: "works_at", Object: "Acme"},
+	{Subject: "John", Predicate: "knows", Object: "Jane"},
+	{Subject: "Acme", Predicate: "located_in", Object: "Berlin"},
+	{Subject: "John", Predicate: "age", Value: "42"},
+	{Subject: "John", Predicate: "is_a", Object: "Person"},
+	{Subject: "Bob", Predicate: "likes", Object: "Tea"},
+}
+
+func TestGraphBuild(t *testing.T) {
+	g := New(testFacts)
+
+	var ids []string
+	for _, n := range g.Nodes() {
+		ids = append(ids, n.ID)
+	}
+	if want := []string{"John", "Acme", "Jane", "Berlin", "Bob", "Tea"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("unexpected nodes %v, want %v", ids, want)
+	}
+	john, _ := g.Node("John")
+	if john.Type != "Person" || !reflect.DeepEqual(john.Values["age"], []string{"42"}) {
+		t.Errorf("unexpected John %+v", john)
+	}
+	if len(g.Edges()) != 5 || g.Degree("Acme") != 3 {
+		t.Errorf("unexpected edges %d, Acme degree %d", len(g.Edges()), g.Degree("Acme"))
+	}
+	if len(g.Facts()) != len(testFacts) {
+		t.Errorf("expected every fact to be kept")
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	g := New(testFacts)
+	if got := g.Neighbors("John"); !reflect.DeepEqual(got, []string{"Acme", "Jane"}) {
+		t.Errorf("unexpected neighbours %v", got)
+	}
+	if got := g.Neighbors("Acme", "works_at"); !reflect.DeepEqual(got, []string{"John", "Jane"}) {
+		t.Errorf("unexpected works_at neighbours %v", got)
+	}
+}
+
+func TestBFS(t *testing.T) {
+	g := New(testFacts)
+	got := g.BFS("Berlin", 1)
+	if want := []Visit{{"Berlin", 0}, {"Acme", 1}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected depth-1 BFS %v", got)
+	}
+	if got := g.BFS("Berlin", -1); len(got) != 4 || got[3].Depth != 2 {
+		t.Errorf("unexpected full BFS %v", got)
+	}
+	if g.BFS("Nobody", 2) != nil {
+		t.Error("expected no visits from an unknown node")
+	}
+}
+
+func TestShortestPath(t *testing.T) {
+	g := New(testFacts)
+
+	path, ok := g.ShortestPath("Berlin", "John")
+	if !ok || len(path) != 2 || path[0].Predicate != "located_in" || path[1].Predicate != "works_at" {
+		t.Errorf("unexpected path %+v", path)
+	}
+	if _, ok := g.ShortestPath("John", "Tea"); ok {
+		t.Error("expected no path between components")
+	}
+	if path, ok := g.ShortestPath("John", "John"); !ok || len(path) != 0 {
+		t.Errorf("expected an empty path to self, got %v", path)
+	}
+}
+
+func TestComponentsAndStats(t *testing.T) {
+	g := New(testFacts)
+
+	want := [][]string{{"John", "Acme", "Jane", "Berlin"}, {"Bob", "Tea"}}
+	if got := g.Components(); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected components %v", got)
+	}
+
+	s := g.Stats()
+	if s.Nodes != 6 || s.Edges != 5 || s.Components != 2 || s.MinDegree != 1 || s.MaxDegree != 3 {
+		t.Errorf("unexpected stats %+v", s)
+	}
+	if !reflect.DeepEqual(s.Hubs, []string{"Acme"}) {
+		t.Errorf("unexpected hubs %v", s.Hubs)
+	}
+}
+
+func TestSubgraph(t *testing.T) {
+	g := New(testFacts)
+
+	sub := g.Neighborhood("John", 1)
+	if sub.Len() != 3 || len(sub.Edges()) != 3 {
+		t.Errorf("unexpected neighbourhood: %d nodes, %d edges", sub.Len(), len(sub.Edges()))
+	}
+	john, _ := sub.Node("John")
+	if john.Type != "Person" || john.Values["age"][0] != "42" {
+		t.Errorf("expected attributes to be kept, got %+v", john)
+	}
+	if _, ok := sub.Node("Berlin"); ok {
+		t.Error("Berlin is two hops away")
+	}
+}
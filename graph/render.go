@@ -0,0 +1,322 @@
+package graph
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	gomind "github.com/ingate/gomind-go-sdk"
+)
+
+// ColorBy selects what the exporters colour.
+type ColorBy int
+
+const (
+	// ColorNone renders everything in the default style.
+	ColorNone ColorBy = iota
+	// ColorByPredicate colours edges by predicate.
+	ColorByPredicate
+	// ColorByType colours entity nodes by Node.Type. Untyped nodes keep
+	// the default style.
+	ColorByType
+)
+
+// DefaultPalette is the palette used when RenderOptions.Palette is empty.
+var DefaultPalette = []string{
+	"#4e79a7", "#f28e2b", "#e15759", "#76b7b2", "#59a14f",
+	"#edc948", "#b07aa1", "#ff9da7", "#9c755f", "#bab0ac",
+}
+
+// RenderOptions configures WriteDOT, WriteMermaid and WriteGraphML.
+type RenderOptions struct {
+	// CollapseValues renders literal values as attributes of their
+	// entity node instead of as separate value nodes.
+	CollapseValues bool
+
+	// ColorBy selects what to colour. Colours are taken from Palette in
+	// order of first appearance of each predicate or type, wrapping
+	// around when there are more keys than colours.
+	ColorBy ColorBy
+	Palette []string
+
+	// MaxNodes caps the number of entity nodes, keeping the most
+	// connected ones (ties go to the earliest). Value nodes of kept
+	// entities are not counted. Zero means no limit.
+	MaxNodes int
+}
+
+// FromRecall builds a graph from a Recall or RecallConnections response.
+func FromRecall(resp *gomind.RecallResponse) *Graph {
+	if resp == nil {
+		return New(nil)
+	}
+	return New(resp.Facts)
+}
+
+type viewNode struct {
+	id      string
+	label   string
+	typ     string
+	literal bool
+	// attrs holds collapsed values as predicate/value pairs.
+	attrs [][2]string
+	color string
+}
+
+type viewEdge struct {
+	from, to  string
+	predicate string
+	color     string
+}
+
+// view is the renderer-independent form of a graph after applying
+// RenderOptions.
+type view struct {
+	nodes   []*viewNode
+	edges   []viewEdge
+	omitted int
+}
+
+func (g *Graph) view(opts RenderOptions) *view {
+	palette := opts.Palette
+	if len(palette) == 0 {
+		palette = DefaultPalette
+	}
+	colors := make(map[string]string)
+	colorOf := func(key string) string {
+		if key == "" {
+			return ""
+		}
+		c, ok := colors[key]
+		if !ok {
+			c = palette[len(colors)%len(palette)]
+			colors[key] = c
+		}
+		return c
+	}
+
+	kept := g.order
+	if opts.MaxNodes > 0 && len(kept) > opts.MaxNodes {
+		byDegree := slices.Clone(kept)
+		slices.SortStableFunc(byDegree, func(a, b string) int { return cmp.Compare(g.Degree(b), g.Degree(a)) })
+		keep := make(map[string]bool, opts.MaxNodes)
+		for _, id := range byDegree[:opts.MaxNodes] {
+			keep[id] = true
+		}
+		kept = slices.DeleteFunc(slices.Clone(kept), func(id string) bool { return !keep[id] })
+	}
+
+	v := &view{omitted: len(g.order) - len(kept)}
+	nodes := make(map[string]*viewNode, len(kept))
+	for i, id := range kept {
+		n := g.nodes[id]
+		vn := &viewNode{id: fmt.Sprintf("n%d", i), label: id, typ: n.Type}
+		if opts.ColorBy == ColorByType {
+			vn.color = colorOf(n.Type)
+		}
+		nodes[id] = vn
+		v.nodes = append(v.nodes, vn)
+	}
+
+	for _, f := range g.facts {
+		from, ok := nodes[f.Subject]
+		if !ok || slices.Contains(TypePredicates, f.Predicate) {
+			continue
+		}
+		var edgeColor string
+		if opts.ColorBy == ColorByPredicate {
+			edgeColor = colorOf(f.Predicate)
+		}
+		switch {
+		case f.Object != "":
+			if to, ok := nodes[f.Object]; ok {
+				v.edges = append(v.edges, viewEdge{from: from.id, to: to.id, predicate: f.Predicate, color: edgeColor})
+			}
+		case f.Value != "" && opts.CollapseValues:
+			from.attrs = append(from.attrs, [2]string{f.Predicate, f.Value})
+		case f.Value != "":
+			lit := &viewNode{id: fmt.Sprintf("v%d", len(v.nodes)), label: f.Value, literal: true}
+			v.nodes = append(v.nodes, lit)
+			v.edges = append(v.edges, viewEdge{from: from.id, to: lit.id, predicate: f.Predicate, color: edgeColor})
+		}
+	}
+	return v
+}
+
+// lines returns the label of n followed by its collapsed attributes.
+func (n *viewNode) lines() []string {
+	first := n.label
+	if n.typ != "" {
+		first += " (" + n.typ + ")"
+	}
+	lines := []string{first}
+	for _, a := range n.attrs {
+		lines = append(lines, a[0]+": "+a[1])
+	}
+	return lines
+}
+
+// WriteDOT renders the graph in Graphviz DOT format.
+func (g *Graph) WriteDOT(w io.Writer, opts RenderOptions) error {
+	v := g.view(opts)
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, "digraph gomind {")
+	fmt.Fprintln(bw, "  rankdir=LR;")
+	fmt.Fprintln(bw, "  node [shape=ellipse];")
+	if v.omitted > 0 {
+		fmt.Fprintf(bw, "  // %d nodes omitted\n", v.omitted)
+	}
+	for _, n := range v.nodes {
+		lines := make([]string, 0, len(n.attrs)+1)
+		for _, l := range n.lines() {
+			lines = append(lines, dotEscape(l))
+		}
+		attrs := []string{`label="` + strings.Join(lines, `\n`) + `"`}
+		if n.literal {
+			attrs = append(attrs, "shape=box")
+		}
+		if n.color != "" {
+			attrs = append(attrs, "style=filled", `fillcolor="`+n.color+`"`)
+		}
+		fmt.Fprintf(bw, "  %s [%s];\n", n.id, strings.Join(attrs, ", "))
+	}
+	for _, e := range v.edges {
+		attrs := []string{`label="` + dotEscape(e.predicate) + `"`}
+		if e.color != "" {
+			attrs = append(attrs, `color="`+e.color+`"`, `fontcolor="`+e.color+`"`)
+		}
+		fmt.Fprintf(bw, "  %s -> %s [%s];\n", e.from, e.to, strings.Join(attrs, ", "))
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// WriteMermaid renders the graph as a Mermaid flowchart.
+func (g *Graph) WriteMermaid(w io.Writer, opts RenderOptions) error {
+	v := g.view(opts)
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, "flowchart LR")
+	if v.omitted > 0 {
+		fmt.Fprintf(bw, "  %%%% %d nodes omitted\n", v.omitted)
+	}
+	for _, n := range v.nodes {
+		lines := make([]string, 0, len(n.attrs)+1)
+		for _, l := range n.lines() {
+			lines = append(lines, mermaidEscape(l))
+		}
+		label := strings.Join(lines, "<br/>")
+		if n.literal {
+			fmt.Fprintf(bw, "  %s[\"%s\"]\n", n.id, label)
+		} else {
+			fmt.Fprintf(bw, "  %s(\"%s\")\n", n.id, label)
+		}
+	}
+	for _, e := range v.edges {
+		fmt.Fprintf(bw, "  %s -->|\"%s\"| %s\n", e.from, mermaidEscape(e.predicate), e.to)
+	}
+	for _, n := range v.nodes {
+		if n.color != "" {
+			fmt.Fprintf(bw, "  style %s fill:%s\n", n.id, n.color)
+		}
+	}
+	for i, e := range v.edges {
+		if e.color != "" {
+			fmt.Fprintf(bw, "  linkStyle %d stroke:%s\n", i, e.color)
+		}
+	}
+	return bw.Flush()
+}
+
+func mermaidEscape(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "\n", "<br/>").Replace(s)
+}
+
+// WriteGraphML renders the graph as GraphML. Nodes carry label, type,
+// kind ("entity" or "value") and color data; edges carry predicate and
+// color. Collapsed values become one data key per predicate, named
+// after it, with multiple values joined by "; ".
+func (g *Graph) WriteGraphML(w io.Writer, opts RenderOptions) error {
+	v := g.view(opts)
+	bw := bufio.NewWriter(w)
+
+	// Data keys for collapsed values, in order of first appearance.
+	var predicates []string
+	for _, n := range v.nodes {
+		for _, a := range n.attrs {
+			if !slices.Contains(predicates, a[0]) {
+				predicates = append(predicates, a[0])
+			}
+		}
+	}
+
+	fmt.Fprintln(bw, xml.Header+`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">`)
+	if v.omitted > 0 {
+		fmt.Fprintf(bw, "  <!-- %d nodes omitted -->\n", v.omitted)
+	}
+	for _, k := range []struct{ id, scope, name string }{
+		{"label", "node", "label"},
+		{"type", "node", "type"},
+		{"kind", "node", "kind"},
+		{"ncolor", "node", "color"},
+		{"predicate", "edge", "predicate"},
+		{"ecolor", "edge", "color"},
+	} {
+		fmt.Fprintf(bw, "  <key id=%q for=%q attr.name=%q attr.type=\"string\"/>\n", k.id, k.scope, k.name)
+	}
+	for i, p := range predicates {
+		fmt.Fprintf(bw, "  <key id=\"attr%d\" for=\"node\" attr.name=\"%s\" attr.type=\"string\"/>\n", i, xmlEscape(p))
+	}
+	fmt.Fprintln(bw, `  <graph id="gomind" edgedefault="directed">`)
+	for _, n := range v.nodes {
+		fmt.Fprintf(bw, "    <node id=%q>\n", n.id)
+		writeData(bw, "label", n.label)
+		writeData(bw, "type", n.typ)
+		if n.literal {
+			writeData(bw, "kind", "value")
+		} else {
+			writeData(bw, "kind", "entity")
+		}
+		writeData(bw, "ncolor", n.color)
+		for i, p := range predicates {
+			var values []string
+			for _, a := range n.attrs {
+				if a[0] == p {
+					values = append(values, a[1])
+				}
+			}
+			writeData(bw, fmt.Sprintf("attr%d", i), strings.Join(values, "; "))
+		}
+		fmt.Fprintln(bw, "    </node>")
+	}
+	for i, e := range v.edges {
+		fmt.Fprintf(bw, "    <edge id=\"e%d\" source=%q target=%q>\n", i, e.from, e.to)
+		writeData(bw, "predicate", e.predicate)
+		writeData(bw, "ecolor", e.color)
+		fmt.Fprintln(bw, "    </edge>")
+	}
+	fmt.Fprintln(bw, "  </graph>")
+	fmt.Fprintln(bw, "</graphml>")
+	return bw.Flush()
+}
+
+func writeData(w io.Writer, key, value string) {
+	if value != "" {
+		fmt.Fprintf(w, "      <data key=%q>%s</data>\n", key, xmlEscape(value))
+	}
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
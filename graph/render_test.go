@@ -0,0 +1,135 @@
+package graph
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	gomind "github.com/ingate/gomind-go-sdk"
+)
+
+func render(t *testing.T, write func(*strings.Builder) error) string {
+	t.Helper()
+	var b strings.Builder
+	if err := write(&b); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	return b.String()
+}
+
+func TestWriteDOT(t *testing.T) {
+	g := New(testFacts)
+
+	out := render(t, func(b *strings.Builder) error { return g.WriteDOT(b, RenderOptions{}) })
+	for _, want := range []string{
+		`n0 [label="John (Person)"];`,
+		`n0 -> n1 [label="works_at"];`,
+		`v6 [label="42", shape=box];`,
+		`n0 -> v6 [label="age"];`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "is_a") {
+		t.Errorf("type facts should not become edges:\n%s", out)
+	}
+
+	out = render(t, func(b *strings.Builder) error {
+		return g.WriteDOT(b, RenderOptions{CollapseValues: true, ColorBy: ColorByPredicate})
+	})
+	if !strings.Contains(out, `n0 [label="John (Person)\nage: 42"];`) || strings.Contains(out, "shape=box") {
+		t.Errorf("expected values to be collapsed:\n%s", out)
+	}
+	if !strings.Contains(out, `n0 -> n1 [label="works_at", color="#4e79a7", fontcolor="#4e79a7"];`) ||
+		!strings.Contains(out, `n2 -> n1 [label="works_at", color="#4e79a7"`) ||
+		!strings.Contains(out, `n0 -> n2 [label="knows", color="#f28e2b"`) {
+		t.Errorf("expected edges coloured by predicate:\n%s", out)
+	}
+}
+
+func TestWriteDOTEscapes(t *testing.T) {
+	g := New([]gomind.Fact{{Subject: `say "hi"`, Predicate: "note", Value: `a\b`}})
+	out := render(t, func(b *strings.Builder) error { return g.WriteDOT(b, RenderOptions{CollapseValues: true}) })
+	if !strings.Contains(out, `label="say \"hi\"\nnote: a\\b"`) {
+		t.Errorf("unexpected escaping:\n%s", out)
+	}
+}
+
+func TestWriteMermaid(t *testing.T) {
+	g := New(testFacts)
+	out := render(t, func(b *strings.Builder) error {
+		return g.WriteMermaid(b, RenderOptions{CollapseValues: true, ColorBy: ColorByType})
+	})
+	for _, want := range []string{
+		"flowchart LR\n",
+		`n0("John (Person)<br/>age: 42")`,
+		`n0 -->|"works_at"| n1`,
+		"style n0 fill:#4e79a7",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "style n1") || strings.Contains(out, "linkStyle") {
+		t.Errorf("only typed nodes should be coloured:\n%s", out)
+	}
+}
+
+func TestWriteGraphML(t *testing.T) {
+	g := New(testFacts)
+	out := render(t, func(b *strings.Builder) error { return g.WriteGraphML(b, RenderOptions{CollapseValues: true}) })
+
+	var doc struct {
+		Graph struct {
+			Nodes []struct {
+				ID   string `xml:"id,attr"`
+				Data []struct {
+					Key   string `xml:"key,attr"`
+					Value string `xml:",chardata"`
+				} `xml:"data"`
+			} `xml:"node"`
+			Edges []struct {
+				Source string `xml:"source,attr"`
+				Target string `xml:"target,attr"`
+			} `xml:"edge"`
+		} `xml:"graph"`
+	}
+	if err := xml.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("invalid GraphML: %v\n%s", err, out)
+	}
+	if len(doc.Graph.Nodes) != 6 || len(doc.Graph.Edges) != 5 {
+		t.Errorf("unexpected size: %d nodes, %d edges", len(doc.Graph.Nodes), len(doc.Graph.Edges))
+	}
+	data := map[string]string{}
+	for _, d := range doc.Graph.Nodes[0].Data {
+		data[d.Key] = d.Value
+	}
+	if data["label"] != "John" || data["type"] != "Person" || data["attr0"] != "42" {
+		t.Errorf("unexpected John data %v", data)
+	}
+	if !strings.Contains(out, `<key id="attr0" for="node" attr.name="age"`) {
+		t.Errorf("missing value key:\n%s", out)
+	}
+}
+
+func TestRenderMaxNodes(t *testing.T) {
+	g := New(testFacts)
+	out := render(t, func(b *strings.Builder) error { return g.WriteDOT(b, RenderOptions{MaxNodes: 2}) })
+
+	// John and Acme are the most connected; everything else is dropped.
+	if !strings.Contains(out, "// 4 nodes omitted") || !strings.Contains(out, `n1 [label="Acme"]`) ||
+		strings.Contains(out, "Jane") || strings.Contains(out, "Berlin") {
+		t.Errorf("unexpected capped graph:\n%s", out)
+	}
+	if !strings.Contains(out, `n0 -> n1 [label="works_at"]`) || strings.Count(out, "->") != 2 {
+		t.Errorf("expected only edges between kept nodes and John's value:\n%s", out)
+	}
+}
+
+func TestFromRecall(t *testing.T) {
+	g := FromRecall(&gomind.RecallResponse{Facts: testFacts})
+	if g.Len() != 6 || FromRecall(nil).Len() != 0 {
+		t.Error("unexpected graph from recall response")
+	}
+}
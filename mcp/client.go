@@ -0,0 +1,236 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+)
+
+// Client is a minimal MCP client speaking newline-delimited JSON-RPC
+// over a byte stream. It is mainly intended for tests and for embedding
+// a Server in-process; see NewInProcessClient.
+type Client struct {
+	w      io.Writer
+	closer func() error
+
+	writeMu sync.Mutex
+	mu      sync.Mutex
+	nextID  int
+	pending map[string]chan *message
+	readErr error
+	done    chan struct{}
+}
+
+// Connect starts a client reading responses from r and writing requests
+// to w. It does not send initialize; call Initialize first.
+func Connect(r io.Reader, w io.Writer) *Client {
+	c := &Client{
+		w:       w,
+		pending: make(map[string]chan *message),
+		done:    make(chan struct{}),
+	}
+	go c.readLoop(r)
+	return c
+}
+
+// NewInProcessClient runs s over in-memory pipes and returns an
+// initialized client connected to it. Close the client to stop the
+// server.
+func NewInProcessClient(ctx context.Context, s *Server) (*Client, error) {
+	clientR, serverW := io.Pipe()
+	serverR, clientW := io.Pipe()
+
+	serveCtx, cancel := context.WithCancel(context.Background())
+	go func() {
+		_ = s.ServeStdio(serveCtx, serverR, serverW)
+		_ = serverW.Close()
+	}()
+
+	c := Connect(clientR, clientW)
+	c.closer = func() error {
+		cancel()
+		return clientW.Close()
+	}
+
+	if _, err := c.Initialize(ctx); err != nil {
+		_ = c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// Close releases the connection.
+func (c *Client) Close() error {
+	if c.closer != nil {
+		return c.closer()
+	}
+	return nil
+}
+
+// Initialize performs the MCP handshake.
+func (c *Client) Initialize(ctx context.Context) (*InitializeResult, error) {
+	var result InitializeResult
+	err := c.call(ctx, "initialize", initializeParams{
+		ProtocolVersion: ProtocolVersion,
+		Capabilities:    map[string]any{},
+		ClientInfo:      Implementation{Name: "gomind-go-sdk", Version: "dev"},
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.notify("notifications/initialized"); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListTools returns the tools offered by the server.
+func (c *Client) ListTools(ctx context.Context) ([]Tool, error) {
+	var result listToolsResult
+	if err := c.call(ctx, "tools/list", struct{}{}, &result); err != nil {
+		return nil, err
+	}
+	return result.Tools, nil
+}
+
+// CallTool invokes a tool. Tool failures are reported through
+// CallToolResult.IsError, not as an error.
+func (c *Client) CallTool(ctx context.Context, name string, arguments any) (*CallToolResult, error) {
+	raw, err := json.Marshal(arguments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tool arguments: %w", err)
+	}
+	var result CallToolResult
+	if err := c.call(ctx, "tools/call", callToolParams{Name: name, Arguments: raw}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListResources returns the resources offered by the server.
+func (c *Client) ListResources(ctx context.Context) ([]Resource, error) {
+	var result listResourcesResult
+	if err := c.call(ctx, "resources/list", struct{}{}, &result); err != nil {
+		return nil, err
+	}
+	return result.Resources, nil
+}
+
+// ReadResource fetches the contents of a resource.
+func (c *Client) ReadResource(ctx context.Context, uri string) ([]ResourceContents, error) {
+	var result readResourceResult
+	if err := c.call(ctx, "resources/read", readResourceParams{URI: uri}, &result); err != nil {
+		return nil, err
+	}
+	return result.Contents, nil
+}
+
+// ListPrompts returns the prompts offered by the server.
+func (c *Client) ListPrompts(ctx context.Context) ([]Prompt, error) {
+	var result listPromptsResult
+	if err := c.call(ctx, "prompts/list", struct{}{}, &result); err != nil {
+		return nil, err
+	}
+	return result.Prompts, nil
+}
+
+// GetPrompt renders a prompt.
+func (c *Client) GetPrompt(ctx context.Context, name string) (*GetPromptResult, error) {
+	var result GetPromptResult
+	if err := c.call(ctx, "prompts/get", getPromptParams{Name: name}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// call sends a request and decodes the result into out.
+func (c *Client) call(ctx context.Context, method string, params any, out any) error {
+	rawParams, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s params: %w", method, err)
+	}
+
+	c.mu.Lock()
+	if c.readErr != nil {
+		c.mu.Unlock()
+		return c.readErr
+	}
+	c.nextID++
+	id := strconv.Itoa(c.nextID)
+	ch := make(chan *message, 1)
+	c.pending[id] = ch
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+	}()
+
+	if err := c.send(message{JSONRPC: "2.0", ID: json.RawMessage(id), Method: method, Params: rawParams}); err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.done:
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return c.readErr
+	case resp := <-ch:
+		if resp.Error != nil {
+			return resp.Error
+		}
+		if err := json.Unmarshal(resp.Result, out); err != nil {
+			return fmt.Errorf("failed to parse %s result: %w", method, err)
+		}
+		return nil
+	}
+}
+
+func (c *Client) notify(method string) error {
+	return c.send(message{JSONRPC: "2.0", Method: method})
+}
+
+func (c *Client) send(msg message) error {
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if _, err := c.w.Write(append(raw, '\n')); err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) readLoop(r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+	for scanner.Scan() {
+		var msg message
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil || msg.isNotification() {
+			continue
+		}
+		c.mu.Lock()
+		ch, ok := c.pending[string(msg.ID)]
+		c.mu.Unlock()
+		if ok {
+			ch <- &msg
+		}
+	}
+
+	c.mu.Lock()
+	c.readErr = scanner.Err()
+	if c.readErr == nil {
+		c.readErr = io.EOF
+	}
+	c.mu.Unlock()
+	close(c.done)
+}
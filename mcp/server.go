@@ -0,0 +1,372 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	gomind "github.com/ingate/gomind-go-sdk"
+	"github.com/ingate/gomind-go-sdk/tools"
+)
+
+// SystemPromptName is the name of the prompt serving the Gomind system
+// prompt.
+const SystemPromptName = "gomind_system_prompt"
+
+// collectionURIPrefix prefixes the URI of every collection resource.
+const collectionURIPrefix = "gomind://collections/"
+
+// maxMessageSize bounds a single JSON-RPC message.
+const maxMessageSize = 10 << 20
+
+// Options configures a Server.
+type Options struct {
+	// Name and Version identify the server to clients. They default to
+	// "gomind" and "dev".
+	Name    string
+	Version string
+
+	// OrgID enables collection resources. Without it resources/list is
+	// empty. Only collections permitted by the Collections allow-list of
+	// the client's ToolPolicy are listed and readable.
+	OrgID string
+
+	// Registry supplies the tools. When nil a registry with the built-in
+	// Gomind tools is created from the client.
+	Registry *gomind.ToolRegistry
+
+	// TextOptions controls how tool results are rendered.
+	TextOptions gomind.ToolTextOptions
+
+	// AllowedOrigins lists the browser origins, e.g.
+	// "https://app.example.com", that may call the HTTP transport.
+	// Requests without an Origin header and from loopback origins
+	// (localhost, 127.0.0.1, [::1]) are always allowed; any other Origin
+	// is rejected to prevent DNS rebinding attacks.
+	AllowedOrigins []string
+}
+
+// Server serves Gomind tools, collections and the system prompt over
+// MCP. It is safe for concurrent use and stateless, so a single Server
+// can back any number of sessions on any transport.
+type Server struct {
+	client   *gomind.Client
+	registry *gomind.ToolRegistry
+	opts     Options
+}
+
+// NewServer creates an MCP server backed by c.
+func NewServer(c *gomind.Client, opts Options) *Server {
+	if opts.Name == "" {
+		opts.Name = "gomind"
+	}
+	if opts.Version == "" {
+		opts.Version = "dev"
+	}
+	registry := opts.Registry
+	if registry == nil {
+		registry = gomind.NewToolRegistry(c)
+	}
+	return &Server{client: c, registry: registry, opts: opts}
+}
+
+// ServeStdio reads newline-delimited JSON-RPC messages from r and writes
+// responses to w until r is exhausted or ctx is cancelled. Requests are
+// handled concurrently; responses may arrive out of order.
+func (s *Server) ServeStdio(ctx context.Context, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		line := slices.Clone(scanner.Bytes())
+		if len(strings.TrimSpace(string(line))) == 0 {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp := s.HandleMessage(ctx, line)
+			if resp == nil {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			_, _ = w.Write(append(resp, '\n'))
+		}()
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read message: %w", err)
+	}
+	return nil
+}
+
+// ServeHTTP implements the MCP streamable HTTP transport in its
+// stateless form: each POST carries one JSON-RPC message and is answered
+// with a single JSON response. Server-initiated streams (GET) are not
+// offered. Browser requests from origins other than loopback and
+// Options.AllowedOrigins are rejected with 403.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if origin := r.Header.Get("Origin"); origin != "" && !s.allowsOrigin(origin) {
+		http.Error(w, "origin not allowed", http.StatusForbidden)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxMessageSize))
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	resp := s.HandleMessage(r.Context(), body)
+	if resp == nil {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(resp)
+}
+
+// allowsOrigin reports whether a browser at origin may use the HTTP
+// transport.
+func (s *Server) allowsOrigin(origin string) bool {
+	if slices.ContainsFunc(s.opts.AllowedOrigins, func(o string) bool { return strings.EqualFold(o, origin) }) {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return false
+	}
+	host := u.Hostname()
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// HandleMessage processes one JSON-RPC message and returns the encoded
+// response, or nil for notifications.
+func (s *Server) HandleMessage(ctx context.Context, raw []byte) []byte {
+	var msg message
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		return encodeResponse(nil, nil, &RPCError{Code: CodeParseError, Message: "parse error"})
+	}
+	if msg.JSONRPC != "2.0" || msg.Method == "" {
+		if msg.isNotification() {
+			return nil
+		}
+		return encodeResponse(msg.ID, nil, &RPCError{Code: CodeInvalidRequest, Message: "invalid request"})
+	}
+
+	result, rpcErr := s.dispatch(ctx, msg.Method, msg.Params)
+	if msg.isNotification() {
+		return nil
+	}
+	return encodeResponse(msg.ID, result, rpcErr)
+}
+
+func (s *Server) dispatch(ctx context.Context, method string, params json.RawMessage) (any, *RPCError) {
+	switch method {
+	case "initialize":
+		var p initializeParams
+		if err := unmarshalParams(params, &p); err != nil {
+			return nil, err
+		}
+		return s.initialize(p), nil
+	case "ping":
+		return struct{}{}, nil
+	case "tools/list":
+		return s.listTools(), nil
+	case "tools/call":
+		var p callToolParams
+		if err := unmarshalParams(params, &p); err != nil {
+			return nil, err
+		}
+		return s.callTool(ctx, p), nil
+	case "resources/list":
+		return s.listResources(ctx)
+	case "resources/read":
+		var p readResourceParams
+		if err := unmarshalParams(params, &p); err != nil {
+			return nil, err
+		}
+		return s.readResource(ctx, p.URI)
+	case "prompts/list":
+		return listPromptsResult{Prompts: []Prompt{{
+			Name:        SystemPromptName,
+			Description: "Recommended system prompt for agents using Gomind memory",
+		}}}, nil
+	case "prompts/get":
+		var p getPromptParams
+		if err := unmarshalParams(params, &p); err != nil {
+			return nil, err
+		}
+		return s.getPrompt(ctx, p.Name)
+	}
+
+	if strings.HasPrefix(method, "notifications/") {
+		return nil, nil
+	}
+	return nil, &RPCError{Code: CodeMethodNotFound, Message: "method not found: " + method}
+}
+
+func (s *Server) initialize(p initializeParams) InitializeResult {
+	version := ProtocolVersion
+	if slices.Contains(supportedVersions, p.ProtocolVersion) {
+		version = p.ProtocolVersion
+	}
+	return InitializeResult{
+		ProtocolVersion: version,
+		Capabilities: map[string]any{
+			"tools":     map[string]any{},
+			"resources": map[string]any{},
+			"prompts":   map[string]any{},
+		},
+		ServerInfo: Implementation{Name: s.opts.Name, Version: s.opts.Version},
+	}
+}
+
+func (s *Server) listTools() listToolsResult {
+	defs := s.registry.Definitions()
+	result := listToolsResult{Tools: make([]Tool, len(defs))}
+	for i, def := range defs {
+		result.Tools[i] = Tool{
+			Name:        def.Name,
+			Description: def.Description,
+			InputSchema: tools.JSONSchema(def.Parameters),
+		}
+	}
+	return result
+}
+
+// callTool runs a tool. Tool failures are reported in the result with
+// isError set, as MCP requires, rather than as protocol errors.
+func (s *Server) callTool(ctx context.Context, p callToolParams) CallToolResult {
+	arguments := string(p.Arguments)
+	if arguments == "" || arguments == "null" {
+		arguments = "{}"
+	}
+
+	res := s.registry.HandleCalls(ctx, []gomind.ToolCall{{Name: p.Name, Arguments: arguments}}, gomind.ToolBatchOptions{})[0]
+	return CallToolResult{
+		Content: []Content{{Type: "text", Text: res.Text(s.opts.TextOptions)}},
+		IsError: res.Err != nil,
+	}
+}
+
+func (s *Server) listResources(ctx context.Context) (any, *RPCError) {
+	result := listResourcesResult{Resources: []Resource{}}
+	if s.opts.OrgID == "" {
+		return result, nil
+	}
+
+	cols, err := s.client.ListCollections(ctx, s.opts.OrgID)
+	if err != nil {
+		return nil, &RPCError{Code: CodeInternalError, Message: err.Error()}
+	}
+	policy := s.client.ToolPolicy()
+	for _, col := range cols {
+		if !policy.AllowsCollection(col.Code) {
+			continue
+		}
+		result.Resources = append(result.Resources, Resource{
+			URI:         collectionURIPrefix + col.Code,
+			Name:        col.Name,
+			Description: col.Description,
+			MimeType:    "application/json",
+		})
+	}
+	return result, nil
+}
+
+func (s *Server) readResource(ctx context.Context, uri string) (any, *RPCError) {
+	code, ok := strings.CutPrefix(uri, collectionURIPrefix)
+	if !ok || code == "" || s.opts.OrgID == "" {
+		return nil, &RPCError{Code: CodeInvalidParams, Message: "unknown resource: " + uri}
+	}
+
+	cols, err := s.client.ListCollections(ctx, s.opts.OrgID)
+	if err != nil {
+		return nil, &RPCError{Code: CodeInternalError, Message: err.Error()}
+	}
+	for _, col := range cols {
+		if col.Code != code || !s.client.ToolPolicy().AllowsCollection(col.Code) {
+			continue
+		}
+		text, err := json.MarshalIndent(col, "", "  ")
+		if err != nil {
+			return nil, &RPCError{Code: CodeInternalError, Message: err.Error()}
+		}
+		return readResourceResult{Contents: []ResourceContents{{
+			URI:      uri,
+			MimeType: "application/json",
+			Text:     string(text),
+		}}}, nil
+	}
+	return nil, &RPCError{Code: CodeInvalidParams, Message: "unknown resource: " + uri}
+}
+
+func (s *Server) getPrompt(ctx context.Context, name string) (any, *RPCError) {
+	if name != SystemPromptName {
+		return nil, &RPCError{Code: CodeInvalidParams, Message: "unknown prompt: " + name}
+	}
+	resp, err := s.client.SystemPrompt(ctx)
+	if err != nil {
+		return nil, &RPCError{Code: CodeInternalError, Message: err.Error()}
+	}
+	return GetPromptResult{
+		Description: "Recommended system prompt for agents using Gomind memory",
+		Messages: []PromptMessage{{
+			Role:    "user",
+			Content: Content{Type: "text", Text: resp.Prompt},
+		}},
+	}, nil
+}
+
+func unmarshalParams(params json.RawMessage, v any) *RPCError {
+	if len(params) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return &RPCError{Code: CodeInvalidParams, Message: "invalid params: " + err.Error()}
+	}
+	return nil
+}
+
+func encodeResponse(id json.RawMessage, result any, rpcErr *RPCError) []byte {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+	resp := message{JSONRPC: "2.0", ID: id, Error: rpcErr}
+	if rpcErr == nil {
+		raw, err := json.Marshal(result)
+		if err != nil {
+			resp.Error = &RPCError{Code: CodeInternalError, Message: err.Error()}
+		} else {
+			resp.Result = raw
+		}
+	}
+	out, _ := json.Marshal(resp)
+	return out
+}
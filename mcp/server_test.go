@@ -0,0 +1,218 @@
+package mcp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gomind "github.com/ingate/gomind-go-sdk"
+)
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	return newTestServerWith(t, gomind.AppendOnlyPolicy(),
+		`[{"id":"col_1","org_id":"org_1","code":"kb","name":"Knowledge Base"}]`)
+}
+
+// newTestServerWith serves collections, a JSON array, from the fake
+// API and installs policy on the client.
+func newTestServerWith(t *testing.T, policy gomind.ToolPolicy, collections string) *Server {
+	t.Helper()
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/recall":
+			_, _ = w.Write([]byte(`{"status":"OK","result":{"facts":[{"subject":"John","predicate":"works_at","object":"Acme"}],"count":1}}`))
+		case "/v1/system-prompt":
+			_, _ = w.Write([]byte(`{"status":"OK","result":{"prompt":"You have memory."}}`))
+		case "/v1/orgs/org_1/collections/":
+			_, _ = w.Write([]byte(`{"status":"OK","result":` + collections + `}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(api.Close)
+
+	client, err := gomind.NewClient("test-key",
+		gomind.WithBaseURL(api.URL),
+		gomind.WithToolPolicy(policy),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return NewServer(client, Options{OrgID: "org_1"})
+}
+
+func TestInProcessClient(t *testing.T) {
+	ctx := context.Background()
+	c, err := NewInProcessClient(ctx, newTestServer(t))
+	if err != nil {
+		t.Fatalf("NewInProcessClient: %v", err)
+	}
+	defer c.Close()
+
+	toolList, err := c.ListTools(ctx)
+	if err != nil {
+		t.Fatalf("ListTools: %v", err)
+	}
+	for _, tool := range toolList {
+		if strings.HasPrefix(tool.Name, "forget") {
+			t.Errorf("policy should hide %s", tool.Name)
+		}
+		if tool.InputSchema["type"] != "object" {
+			t.Errorf("%s: expected object input schema", tool.Name)
+		}
+	}
+
+	res, err := c.CallTool(ctx, "recall", map[string]any{"query": "John"})
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	if res.IsError || !strings.Contains(res.Content[0].Text, "John,works_at,Acme") {
+		t.Errorf("unexpected recall result: %+v", res)
+	}
+
+	res, err = c.CallTool(ctx, "forget_entity", map[string]any{"entity": "John"})
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	if !res.IsError {
+		t.Errorf("expected forbidden tool to report isError, got %+v", res)
+	}
+
+	resources, err := c.ListResources(ctx)
+	if err != nil {
+		t.Fatalf("ListResources: %v", err)
+	}
+	if len(resources) != 1 || resources[0].URI != "gomind://collections/kb" {
+		t.Fatalf("unexpected resources: %+v", resources)
+	}
+	contents, err := c.ReadResource(ctx, resources[0].URI)
+	if err != nil {
+		t.Fatalf("ReadResource: %v", err)
+	}
+	if !strings.Contains(contents[0].Text, `"code": "kb"`) {
+		t.Errorf("unexpected resource contents: %+v", contents)
+	}
+	if _, err := c.ReadResource(ctx, "gomind://collections/missing"); err == nil {
+		t.Errorf("expected unknown resource to fail")
+	}
+
+	prompt, err := c.GetPrompt(ctx, SystemPromptName)
+	if err != nil {
+		t.Fatalf("GetPrompt: %v", err)
+	}
+	if prompt.Messages[0].Content.Text != "You have memory." {
+		t.Errorf("unexpected prompt: %+v", prompt)
+	}
+}
+
+// TestResourcesRespectPolicy verifies collections outside the policy's
+// allow-list are neither listed nor readable.
+func TestResourcesRespectPolicy(t *testing.T) {
+	ctx := context.Background()
+	server := newTestServerWith(t, gomind.ReadOnlyPolicy().WithCollections("kb"),
+		`[{"id":"col_1","org_id":"org_1","code":"kb","name":"Knowledge Base"},{"id":"col_2","org_id":"org_1","code":"hr","name":"HR"}]`)
+	c, err := NewInProcessClient(ctx, server)
+	if err != nil {
+		t.Fatalf("NewInProcessClient: %v", err)
+	}
+	defer c.Close()
+
+	resources, err := c.ListResources(ctx)
+	if err != nil {
+		t.Fatalf("ListResources: %v", err)
+	}
+	if len(resources) != 1 || resources[0].URI != "gomind://collections/kb" {
+		t.Fatalf("expected only kb, got %+v", resources)
+	}
+	if _, err := c.ReadResource(ctx, "gomind://collections/kb"); err != nil {
+		t.Errorf("ReadResource kb: %v", err)
+	}
+	if _, err := c.ReadResource(ctx, "gomind://collections/hr"); err == nil {
+		t.Error("expected a collection outside the policy to be unreadable")
+	}
+}
+
+func TestStreamableHTTP(t *testing.T) {
+	srv := httptest.NewServer(newTestServer(t))
+	defer srv.Close()
+
+	post := func(body string) (*http.Response, string) {
+		t.Helper()
+		resp, err := http.Post(srv.URL, "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("POST: %v", err)
+		}
+		defer resp.Body.Close()
+		buf := new(strings.Builder)
+		_, _ = io.Copy(buf, resp.Body)
+		return resp, buf.String()
+	}
+
+	resp, body := post(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26"}}`)
+	if resp.StatusCode != http.StatusOK || !strings.Contains(body, `"protocolVersion":"2025-03-26"`) {
+		t.Errorf("unexpected initialize response %d %s", resp.StatusCode, body)
+	}
+
+	resp, _ = post(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)
+	if resp.StatusCode != http.StatusAccepted {
+		t.Errorf("expected 202 for notification, got %d", resp.StatusCode)
+	}
+
+	_, body = post(`{"jsonrpc":"2.0","id":"x","method":"nope"}`)
+	if !strings.Contains(body, `"code":-32601`) || !strings.Contains(body, `"id":"x"`) {
+		t.Errorf("expected method-not-found error, got %s", body)
+	}
+
+	_, body = post(`{not json`)
+	if !strings.Contains(body, `"code":-32700`) {
+		t.Errorf("expected parse error, got %s", body)
+	}
+
+	for origin, want := range map[string]int{
+		"http://localhost:3000": http.StatusOK,
+		"http://127.0.0.1":      http.StatusOK,
+		"https://evil.example":  http.StatusForbidden,
+		"null":                  http.StatusForbidden,
+	} {
+		req, _ := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader(`{"jsonrpc":"2.0","id":2,"method":"ping"}`))
+		req.Header.Set("Origin", origin)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("POST: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("Origin %s: expected %d, got %d", origin, want, resp.StatusCode)
+		}
+	}
+
+	getResp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	getResp.Body.Close()
+	if getResp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405 for GET, got %d", getResp.StatusCode)
+	}
+}
+
+func TestAllowedOrigins(t *testing.T) {
+	s := newTestServer(t)
+	s.opts.AllowedOrigins = []string{"https://app.example"}
+	for origin, want := range map[string]bool{
+		"https://app.example":      true,
+		"https://APP.example":      true,
+		"http://[::1]:8080":        true,
+		"https://app.example.evil": false,
+		"file://":                  false,
+	} {
+		if got := s.allowsOrigin(origin); got != want {
+			t.Errorf("allowsOrigin(%q) = %v, want %v", origin, got, want)
+		}
+	}
+}
@@ -43,16 +43,24 @@ func (c *Client) Mind(ctx context.Context, prompt string, context_ map[string]st
 func (c *Client) MindWithOptions(ctx context.Context, req MindRequest) (*MindResponse, error) {
 	req.Collection = c.resolveCollection(req.Collection)
 
+	if err := c.checkUsage(ctx, req.Collection); err != nil {
+		c.logger.Error("Gomind Mind rejected", "error", err)
+		return nil, err
+	}
+
 	respBody, err := c.post(ctx, "/v1/mind", req)
 	if err != nil {
+		c.recordUsage(ctx, req.Collection, MindMeta{}, true)
 		c.logger.Error("Gomind Mind failed", "error", err)
 		return nil, err
 	}
 
 	var resp APIResponse[MindResponse]
 	if err := json.Unmarshal(respBody, &resp); err != nil {
+		c.recordUsage(ctx, req.Collection, MindMeta{}, true)
 		return nil, fmt.Errorf("failed to parse mind response: %w", err)
 	}
+	c.recordUsage(ctx, req.Collection, resp.Result.Meta, false)
 
 	c.logger.Info("Gomind Mind success",
 		"tokensUsed", resp.Result.Meta.TokensUsed,
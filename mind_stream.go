@@ -0,0 +1,237 @@
+package gomind
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+	"time"
+)
+
+// MindEventType identifies the kind of a MindEvent.
+type MindEventType string
+
+const (
+	// MindEventToolCall is emitted when the internal agent starts a
+	// tool call.
+	MindEventToolCall MindEventType = "tool_call"
+	// MindEventToolResult is emitted when a tool call finishes.
+	MindEventToolResult MindEventType = "tool_result"
+	// MindEventPartial carries a chunk of the agent's output as it is
+	// generated.
+	MindEventPartial MindEventType = "partial"
+	// MindEventResult carries the final result and metadata. It is
+	// always the last event of a successful stream.
+	MindEventResult MindEventType = "result"
+)
+
+// MindToolCall describes a tool call made by the internal agent.
+type MindToolCall struct {
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+}
+
+// MindToolResult describes the outcome of a tool call made by the
+// internal agent.
+type MindToolResult struct {
+	ID     string          `json:"id"`
+	Name   string          `json:"name"`
+	Output json.RawMessage `json:"output,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// MindEvent is a single event of a streamed mind request. Exactly one
+// of the payload fields is set, matching Type.
+type MindEvent struct {
+	Type       MindEventType
+	ToolCall   *MindToolCall
+	ToolResult *MindToolResult
+	Partial    string
+	Result     *MindResponse
+}
+
+// streamMindRequest is the wire body for a streamed mind request.
+type streamMindRequest struct {
+	MindRequest
+	Stream bool `json:"stream"`
+}
+
+// MindStream issues a mind request and streams the agent's progress as
+// server-sent events. Iterate the returned sequence to receive events;
+// the final event has Type MindEventResult. Errors, including a stream
+// that ends without a result, are yielded once as the last element.
+// Breaking out of the loop or cancelling ctx closes the connection.
+//
+// Usage is recorded when the stream ends, including when the loop breaks
+// early. The server reports tokens only in the result event, so a stream
+// abandoned before it is recorded as a request with the latency so far
+// and no tokens.
+//
+// The client timeout (WithTimeout) does not apply to streams; use a
+// context deadline instead.
+func (c *Client) MindStream(ctx context.Context, req MindRequest) iter.Seq2[MindEvent, error] {
+	return func(yield func(MindEvent, error) bool) {
+		req.Collection = c.resolveCollection(req.Collection)
+
+		if err := c.checkUsage(ctx, req.Collection); err != nil {
+			c.logger.Error("Gomind MindStream rejected", "error", err)
+			yield(MindEvent{}, err)
+			return
+		}
+
+		start := time.Now()
+		resp, err := c.postStream(ctx, "/v1/mind", streamMindRequest{MindRequest: req, Stream: true})
+		if err != nil {
+			c.recordUsage(ctx, req.Collection, MindMeta{}, true)
+			c.logger.Error("Gomind MindStream failed", "error", err)
+			yield(MindEvent{}, err)
+			return
+		}
+		defer resp.Body.Close()
+
+		for sse, err := range readSSE(resp.Body) {
+			if err != nil {
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					err = ctxErr
+				}
+				c.recordUsage(ctx, req.Collection, MindMeta{}, true)
+				c.logger.Error("Gomind MindStream failed", "error", err)
+				yield(MindEvent{}, err)
+				return
+			}
+
+			event, err := parseMindEvent(sse)
+			if err != nil {
+				c.recordUsage(ctx, req.Collection, MindMeta{}, true)
+				c.logger.Error("Gomind MindStream failed", "error", err)
+				yield(MindEvent{}, err)
+				return
+			}
+			if event == nil {
+				continue
+			}
+			if event.Type == MindEventResult {
+				c.recordUsage(ctx, req.Collection, event.Result.Meta, false)
+			}
+			if !yield(*event, nil) {
+				if event.Type != MindEventResult {
+					meta := MindMeta{LatencyMs: int(time.Since(start).Milliseconds())}
+					c.recordUsage(ctx, req.Collection, meta, false)
+					c.logger.Info("Gomind MindStream stopped", "latencyMs", meta.LatencyMs)
+				}
+				return
+			}
+			if event.Type == MindEventResult {
+				c.logger.Info("Gomind MindStream success",
+					"tokensUsed", event.Result.Meta.TokensUsed,
+					"latencyMs", event.Result.Meta.LatencyMs,
+				)
+				return
+			}
+		}
+
+		err = fmt.Errorf("mind stream ended without a result: %w", io.ErrUnexpectedEOF)
+		c.recordUsage(ctx, req.Collection, MindMeta{}, true)
+		c.logger.Error("Gomind MindStream failed", "error", err)
+		yield(MindEvent{}, err)
+	}
+}
+
+// sseEvent is one dispatched server-sent event.
+type sseEvent struct {
+	name string
+	data string
+}
+
+// readSSE parses a text/event-stream body. Read errors are yielded; a
+// cleanly closed stream simply ends the sequence.
+func readSSE(r io.Reader) iter.Seq2[sseEvent, error] {
+	return func(yield func(sseEvent, error) bool) {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), 10<<20)
+
+		var name string
+		var data []string
+		for scanner.Scan() {
+			line := scanner.Text()
+			if line == "" {
+				if len(data) > 0 {
+					if !yield(sseEvent{name: name, data: strings.Join(data, "\n")}, nil) {
+						return
+					}
+				}
+				name, data = "", nil
+				continue
+			}
+			if strings.HasPrefix(line, ":") {
+				continue
+			}
+
+			field, value, _ := strings.Cut(line, ":")
+			value = strings.TrimPrefix(value, " ")
+			switch field {
+			case "event":
+				name = value
+			case "data":
+				data = append(data, value)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			yield(sseEvent{}, fmt.Errorf("failed to read mind stream: %w", err))
+			return
+		}
+		if len(data) > 0 {
+			yield(sseEvent{name: name, data: strings.Join(data, "\n")}, nil)
+		}
+	}
+}
+
+// parseMindEvent decodes an SSE event. Unknown event names are skipped
+// (nil, nil) so the server can add event types without breaking clients.
+func parseMindEvent(sse sseEvent) (*MindEvent, error) {
+	switch MindEventType(sse.name) {
+	case MindEventToolCall:
+		var call MindToolCall
+		if err := json.Unmarshal([]byte(sse.data), &call); err != nil {
+			return nil, fmt.Errorf("failed to parse mind tool_call event: %w", err)
+		}
+		return &MindEvent{Type: MindEventToolCall, ToolCall: &call}, nil
+
+	case MindEventToolResult:
+		var result MindToolResult
+		if err := json.Unmarshal([]byte(sse.data), &result); err != nil {
+			return nil, fmt.Errorf("failed to parse mind tool_result event: %w", err)
+		}
+		return &MindEvent{Type: MindEventToolResult, ToolResult: &result}, nil
+
+	case MindEventPartial:
+		var partial struct {
+			Delta string `json:"delta"`
+		}
+		if err := json.Unmarshal([]byte(sse.data), &partial); err != nil {
+			return nil, fmt.Errorf("failed to parse mind partial event: %w", err)
+		}
+		return &MindEvent{Type: MindEventPartial, Partial: partial.Delta}, nil
+
+	case MindEventResult:
+		var result MindResponse
+		if err := json.Unmarshal([]byte(sse.data), &result); err != nil {
+			return nil, fmt.Errorf("failed to parse mind result event: %w", err)
+		}
+		return &MindEvent{Type: MindEventResult, Result: &result}, nil
+
+	case "error":
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal([]byte(sse.data), &apiErr); err != nil || apiErr.Error == "" {
+			return nil, fmt.Errorf("mind stream error: %s", sse.data)
+		}
+		return nil, fmt.Errorf("mind stream error: %s", apiErr.Error)
+	}
+	return nil, nil
+}
@@ -0,0 +1,163 @@
+package gomind
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newSSEServer(t *testing.T, events ...string) *Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get("Accept") != "text/event-stream" || !strings.Contains(string(body), `"stream":true`) {
+			t.Errorf("expected streaming request, got accept=%q body=%s", r.Header.Get("Accept"), body)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, e := range events {
+			fmt.Fprint(w, e)
+			w.(http.Flusher).Flush()
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := NewClient("test-key", WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
+
+func TestMindStream(t *testing.T) {
+	client := newSSEServer(t,
+		": keep-alive\n\n",
+		"event: tool_call\ndata: {\"id\":\"t1\",\"name\":\"recall\",\"arguments\":{\"query\":\"John\"}}\n\n",
+		"event: tool_result\ndata: {\"id\":\"t1\",\"name\":\"recall\",\"output\":{\"count\":1}}\n\n",
+		"event: partial\ndata: {\"delta\":\"John works\"}\n\n",
+		"event: future_event\ndata: {}\n\n",
+		"event: result\ndata: {\"result\":{\"employer\":\"Acme\"},\n",
+		"data: \"meta\":{\"tokens_used\":12,\"latency_ms\":30}}\n\n",
+	)
+
+	var types []string
+	var final *MindResponse
+	for event, err := range client.MindStream(context.Background(), MindRequest{Prompt: "Where does John work?"}) {
+		if err != nil {
+			t.Fatalf("MindStream: %v", err)
+		}
+		types = append(types, string(event.Type))
+		switch event.Type {
+		case MindEventToolCall:
+			if event.ToolCall.Name != "recall" {
+				t.Errorf("unexpected tool call %+v", event.ToolCall)
+			}
+		case MindEventPartial:
+			if event.Partial != "John works" {
+				t.Errorf("unexpected partial %q", event.Partial)
+			}
+		case MindEventResult:
+			final = event.Result
+		}
+	}
+
+	if got := strings.Join(types, ","); got != "tool_call,tool_result,partial,result" {
+		t.Errorf("unexpected event sequence %s", got)
+	}
+	if final == nil || final.Result["employer"] != "Acme" || final.Meta.TokensUsed != 12 {
+		t.Errorf("unexpected final result %+v", final)
+	}
+}
+
+func TestMindStreamErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []string
+		want   string
+	}{
+		{"server error event", []string{"event: error\ndata: {\"error\":\"budget exhausted\"}\n\n"}, "budget exhausted"},
+		{"truncated stream", []string{"event: partial\ndata: {\"delta\":\"x\"}\n\n"}, "without a result"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := newSSEServer(t, tc.events...)
+			var lastErr error
+			for _, err := range client.MindStream(context.Background(), MindRequest{Prompt: "x"}) {
+				lastErr = err
+			}
+			if lastErr == nil || !strings.Contains(lastErr.Error(), tc.want) {
+				t.Errorf("expected error containing %q, got %v", tc.want, lastErr)
+			}
+		})
+	}
+}
+
+// TestMindStreamEarlyStopRecordsUsage verifies a loop that breaks before
+// the result still counts the request.
+func TestMindStreamEarlyStopRecordsUsage(t *testing.T) {
+	client := newSSEServer(t,
+		"event: partial\ndata: {\"delta\":\"John\"}\n\n",
+		"event: result\ndata: {\"result\":{},\"meta\":{\"tokens_used\":12}}\n\n",
+	)
+	client.usage = NewUsageAccountant(UsageOptions{})
+
+	for _, err := range client.MindStream(context.Background(), MindRequest{Prompt: "x"}) {
+		if err != nil {
+			t.Fatalf("MindStream: %v", err)
+		}
+		break
+	}
+	if total := client.Usage().Snapshot().Total; total.Requests != 1 || total.Failures != 0 {
+		t.Errorf("expected one request after an early stop, got %+v", total)
+	}
+
+	for range client.MindStream(context.Background(), MindRequest{Prompt: "x"}) {
+	}
+	if total := client.Usage().Snapshot().Total; total.Requests != 2 || total.TokensUsed != 12 {
+		t.Errorf("expected a completed stream to add its tokens once, got %+v", total)
+	}
+}
+
+// TestMindStreamCancel verifies cancelling the context ends a stream
+// that is still open.
+func TestMindStreamCancel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: partial\ndata: {\"delta\":\"thinking\"}\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	client, err := NewClient("test-key", WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		var lastErr error
+		for event, err := range client.MindStream(ctx, MindRequest{Prompt: "x"}) {
+			if event.Type == MindEventPartial {
+				cancel()
+			}
+			lastErr = err
+		}
+		done <- lastErr
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream did not stop after cancellation")
+	}
+}
@@ -0,0 +1,258 @@
+package gomind
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// MindTemplate is a reusable mind prompt with {{placeholder}} variables.
+// It is parsed once and validates the variables supplied on each use, so
+// a missing or unexpected variable fails before any network call.
+//
+// Placeholders are written {{name}} (surrounding spaces inside the
+// braces are ignored); names may contain letters, digits, '_', '-' and
+// '.'. Write \{{ for a literal "{{" that is not a placeholder.
+//
+// The server interpolates the prompt itself, so requests carry the
+// template in the server's {{name}} syntax with the variables in
+// Context, rather than the rendered text. Variable values are therefore
+// never interpolated again, and an escaped "{{" is sent through an extra
+// variable holding "{{" so the server does not treat it as a
+// placeholder.
+//
+// A MindTemplate is immutable and safe for concurrent use.
+type MindTemplate struct {
+	source       string
+	segments     []templateSegment
+	placeholders []string
+	defaults     map[string]string
+
+	// serverPrompt is the template in server syntax; escapeVar, if set,
+	// is the variable standing in for an escaped "{{".
+	serverPrompt string
+	escapeVar    string
+}
+
+// templateSegment is either literal text or a placeholder reference.
+type templateSegment struct {
+	text        string
+	placeholder bool
+}
+
+// TemplateVarsError reports variables that do not match a MindTemplate.
+type TemplateVarsError struct {
+	Missing    []string
+	Unexpected []string
+}
+
+func (e *TemplateVarsError) Error() string {
+	var parts []string
+	if len(e.Missing) > 0 {
+		parts = append(parts, "missing variables: "+strings.Join(e.Missing, ", "))
+	}
+	if len(e.Unexpected) > 0 {
+		parts = append(parts, "unexpected variables: "+strings.Join(e.Unexpected, ", "))
+	}
+	return "mind template: " + strings.Join(parts, "; ")
+}
+
+// NewMindTemplate parses prompt. defaults supplies values for
+// placeholders the caller may omit; a default for a name that is not a
+// placeholder is an error.
+func NewMindTemplate(prompt string, defaults map[string]string) (*MindTemplate, error) {
+	t := &MindTemplate{source: prompt, defaults: maps.Clone(defaults)}
+
+	var literal strings.Builder
+	rest := prompt
+	for rest != "" {
+		i := strings.Index(rest, "{{")
+		if i < 0 {
+			literal.WriteString(rest)
+			break
+		}
+		if i > 0 && rest[i-1] == '\\' {
+			literal.WriteString(rest[:i-1])
+			literal.WriteString("{{")
+			rest = rest[i+2:]
+			continue
+		}
+		literal.WriteString(rest[:i])
+
+		end := strings.Index(rest[i+2:], "}}")
+		if end < 0 {
+			return nil, fmt.Errorf("mind template: unterminated placeholder at offset %d", len(prompt)-len(rest)+i)
+		}
+		name := strings.TrimSpace(rest[i+2 : i+2+end])
+		if !validPlaceholderName(name) {
+			return nil, fmt.Errorf("mind template: invalid placeholder name %q", name)
+		}
+
+		if literal.Len() > 0 {
+			t.segments = append(t.segments, templateSegment{text: literal.String()})
+			literal.Reset()
+		}
+		t.segments = append(t.segments, templateSegment{text: name, placeholder: true})
+		if !slices.Contains(t.placeholders, name) {
+			t.placeholders = append(t.placeholders, name)
+		}
+		rest = rest[i+2+end+2:]
+	}
+	if literal.Len() > 0 {
+		t.segments = append(t.segments, templateSegment{text: literal.String()})
+	}
+
+	for name := range defaults {
+		if !slices.Contains(t.placeholders, name) {
+			return nil, fmt.Errorf("mind template: default for unknown placeholder %q", name)
+		}
+	}
+	t.buildServerPrompt()
+	return t, nil
+}
+
+// buildServerPrompt writes the template in the server's syntax, routing
+// literal "{{" through a variable whose name no placeholder uses.
+func (t *MindTemplate) buildServerPrompt() {
+	var sb strings.Builder
+	for _, seg := range t.segments {
+		if seg.placeholder {
+			sb.WriteString("{{" + seg.text + "}}")
+			continue
+		}
+		if strings.Contains(seg.text, "{{") && t.escapeVar == "" {
+			t.escapeVar = "lbrace"
+			for slices.Contains(t.placeholders, t.escapeVar) {
+				t.escapeVar += "_"
+			}
+		}
+		sb.WriteString(strings.ReplaceAll(seg.text, "{{", "{{"+t.escapeVar+"}}"))
+	}
+	t.serverPrompt = sb.String()
+}
+
+// MustMindTemplate is like NewMindTemplate but panics on error. It is
+// intended for package-level template declarations.
+func MustMindTemplate(prompt string, defaults map[string]string) *MindTemplate {
+	t, err := NewMindTemplate(prompt, defaults)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Placeholders returns the placeholder names in order of first use.
+func (t *MindTemplate) Placeholders() []string {
+	return slices.Clone(t.placeholders)
+}
+
+// Validate checks vars against the template. Placeholders with a
+// default may be omitted. It returns a *TemplateVarsError on mismatch.
+func (t *MindTemplate) Validate(vars map[string]string) error {
+	var vErr TemplateVarsError
+	for _, name := range t.placeholders {
+		if _, ok := vars[name]; ok {
+			continue
+		}
+		if _, ok := t.defaults[name]; !ok {
+			vErr.Missing = append(vErr.Missing, name)
+		}
+	}
+	for name := range vars {
+		if !slices.Contains(t.placeholders, name) {
+			vErr.Unexpected = append(vErr.Unexpected, name)
+		}
+	}
+	if vErr.Missing == nil && vErr.Unexpected == nil {
+		return nil
+	}
+	slices.Sort(vErr.Unexpected)
+	return &vErr
+}
+
+// Render validates vars and returns the interpolated prompt, as the
+// server will see it.
+func (t *MindTemplate) Render(vars map[string]string) (string, error) {
+	if err := t.Validate(vars); err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	for _, seg := range t.segments {
+		if !seg.placeholder {
+			sb.WriteString(seg.text)
+			continue
+		}
+		if v, ok := vars[seg.text]; ok {
+			sb.WriteString(v)
+		} else {
+			sb.WriteString(t.defaults[seg.text])
+		}
+	}
+	return sb.String(), nil
+}
+
+// Request validates vars and builds a MindRequest whose Prompt is the
+// template in server syntax and whose Context holds the variables,
+// defaults included.
+func (t *MindTemplate) Request(vars map[string]string, outputSchema map[string]any) (MindRequest, error) {
+	if err := t.Validate(vars); err != nil {
+		return MindRequest{}, err
+	}
+	values := make(map[string]string, len(t.placeholders)+1)
+	for _, name := range t.placeholders {
+		if v, ok := vars[name]; ok {
+			values[name] = v
+		} else {
+			values[name] = t.defaults[name]
+		}
+	}
+	if t.escapeVar != "" {
+		values[t.escapeVar] = "{{"
+	}
+	if len(values) == 0 {
+		values = nil
+	}
+	return MindRequest{Prompt: t.serverPrompt, Context: values, OutputSchema: outputSchema}, nil
+}
+
+// String returns the template source.
+func (t *MindTemplate) String() string {
+	return t.source
+}
+
+// MindTemplate validates vars against t and issues a mind request.
+// Variable errors are returned before any request is made.
+func (c *Client) MindTemplate(ctx context.Context, t *MindTemplate, vars map[string]string, outputSchema map[string]any) (*MindResponse, error) {
+	req, err := t.Request(vars, outputSchema)
+	if err != nil {
+		return nil, err
+	}
+	return c.MindWithOptions(ctx, req)
+}
+
+// MindTemplateAs combines MindTemplate and MindAs: it validates vars
+// against t and decodes the result into a T.
+func MindTemplateAs[T any](ctx context.Context, c *Client, t *MindTemplate, vars map[string]string) (*T, MindMeta, error) {
+	req, err := t.Request(vars, nil)
+	if err != nil {
+		return nil, MindMeta{}, err
+	}
+	return MindAsWithOptions[T](ctx, c, req)
+}
+
+func validPlaceholderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-', r == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,119 @@
+package gomind
+
+import (
+	"context"
+	"errors"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestMindTemplateRender(t *testing.T) {
+	tmpl, err := NewMindTemplate(`List people at {{ company }} in {{city}}. Reply as \{{json}}, {{company}} only.`,
+		map[string]string{"city": "Berlin"})
+	if err != nil {
+		t.Fatalf("NewMindTemplate: %v", err)
+	}
+	if got := strings.Join(tmpl.Placeholders(), ","); got != "company,city" {
+		t.Errorf("unexpected placeholders %q", got)
+	}
+
+	got, err := tmpl.Render(map[string]string{"company": "Acme"})
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if want := "List people at Acme in Berlin. Reply as {{json}}, Acme only."; got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+
+	got, _ = tmpl.Render(map[string]string{"company": "Acme", "city": "Paris"})
+	if !strings.Contains(got, "in Paris") {
+		t.Errorf("expected supplied value to override default, got %q", got)
+	}
+
+	_, err = tmpl.Render(map[string]string{"city": "Paris", "country": "FR"})
+	var varsErr *TemplateVarsError
+	if !errors.As(err, &varsErr) {
+		t.Fatalf("expected *TemplateVarsError, got %v", err)
+	}
+	if strings.Join(varsErr.Missing, ",") != "company" || strings.Join(varsErr.Unexpected, ",") != "country" {
+		t.Errorf("unexpected error %+v", varsErr)
+	}
+}
+
+func TestNewMindTemplateErrors(t *testing.T) {
+	tests := []struct {
+		prompt   string
+		defaults map[string]string
+	}{
+		{"Hello {{name", nil},
+		{"Hello {{}}", nil},
+		{"Hello {{first name}}", nil},
+		{"Hello {{name}}", map[string]string{"other": "x"}},
+	}
+	for _, tc := range tests {
+		if _, err := NewMindTemplate(tc.prompt, tc.defaults); err == nil {
+			t.Errorf("NewMindTemplate(%q) expected error", tc.prompt)
+		}
+	}
+}
+
+func TestMindTemplateRequest(t *testing.T) {
+	tmpl := MustMindTemplate(`Reply as \{{json}} about {{name}} in {{lbrace}}.`, map[string]string{"lbrace": "Berlin"})
+	req, err := tmpl.Request(map[string]string{"name": "{{secret}}"}, nil)
+	if err != nil {
+		t.Fatalf("Request: %v", err)
+	}
+	if want := "Reply as {{lbrace_}}json}} about {{name}} in {{lbrace}}."; req.Prompt != want {
+		t.Errorf("Prompt = %q, want %q", req.Prompt, want)
+	}
+	want := map[string]string{"name": "{{secret}}", "lbrace": "Berlin", "lbrace_": "{{"}
+	if !maps.Equal(req.Context, want) {
+		t.Errorf("Context = %v, want %v", req.Context, want)
+	}
+
+	req, _ = MustMindTemplate("No variables.", nil).Request(nil, nil)
+	if req.Prompt != "No variables." || req.Context != nil {
+		t.Errorf("unexpected request %+v", req)
+	}
+}
+
+// TestClientMindTemplate verifies variable errors fail before the
+// network call and valid calls send the template with its variables.
+func TestClientMindTemplate(t *testing.T) {
+	var hits atomic.Int32
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		raw, _ := io.ReadAll(r.Body)
+		body = string(raw)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"OK","result":{"result":{"answer":"yes"},"meta":{}}}`))
+	}))
+	defer srv.Close()
+
+	client, err := NewClient("test-key", WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	tmpl := MustMindTemplate("Is {{name}} employed?", nil)
+	schema := map[string]any{"answer": map[string]any{"type": "string"}}
+
+	if _, err := client.MindTemplate(context.Background(), tmpl, nil, schema); err == nil {
+		t.Fatalf("expected missing variable error")
+	}
+	if hits.Load() != 0 {
+		t.Fatalf("expected no request for invalid variables")
+	}
+
+	if _, err := client.MindTemplate(context.Background(), tmpl, map[string]string{"name": "John"}, schema); err != nil {
+		t.Fatalf("MindTemplate: %v", err)
+	}
+	if !strings.Contains(body, `"prompt":"Is {{name}} employed?"`) || !strings.Contains(body, `"context":{"name":"John"}`) {
+		t.Errorf("unexpected request body %s", body)
+	}
+}
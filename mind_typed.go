@@ -0,0 +1,413 @@
+package gomind
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// SchemaMismatchError is returned by MindAs when the result does not
+// match the output schema derived from the target type.
+type SchemaMismatchError struct {
+	// Path locates the offending value, e.g. "people[2].age".
+	Path string
+	// Expected is the schema type (string, integer, number, boolean,
+	// array, object).
+	Expected string
+	// Got is the JSON type received, or "missing".
+	Got string
+}
+
+func (e *SchemaMismatchError) Error() string {
+	return fmt.Sprintf("mind result does not match schema at %s: expected %s, got %s", e.Path, e.Expected, e.Got)
+}
+
+// MindAs makes a mind request whose output schema is derived from T and
+// decodes the result into a T.
+//
+// T must be a struct. Each exported field becomes a schema field named
+// after its json tag, described by its `description:"..."` tag.
+// Strings, booleans, integers, floats, time.Time (as a string), slices,
+// string-keyed maps and nested structs are supported; recursive types
+// are not. Fields of embedded structs are promoted as in encoding/json.
+// Fields that are
+// pointers or tagged omitempty may be absent from the result; any other
+// missing or mistyped field yields a *SchemaMismatchError.
+func MindAs[T any](ctx context.Context, c *Client, prompt string, vars map[string]string) (*T, MindMeta, error) {
+	return MindAsWithOptions[T](ctx, c, MindRequest{
+		Prompt:  prompt,
+		Context: vars,
+	})
+}
+
+// MindAsWithOptions is MindAs with full control over the request
+// payload. req.OutputSchema is replaced by the schema derived from T.
+func MindAsWithOptions[T any](ctx context.Context, c *Client, req MindRequest) (*T, MindMeta, error) {
+	schema, err := OutputSchemaFor[T]()
+	if err != nil {
+		return nil, MindMeta{}, err
+	}
+	req.OutputSchema = schema
+
+	resp, err := c.MindWithOptions(ctx, req)
+	if err != nil {
+		return nil, MindMeta{}, err
+	}
+
+	out, err := decodeMindResult[T](resp.Result)
+	if err != nil {
+		return nil, resp.Meta, err
+	}
+	return out, resp.Meta, nil
+}
+
+// OutputSchemaFor returns the mind output schema derived from T. See
+// MindAs for the mapping rules.
+func OutputSchemaFor[T any]() (map[string]any, error) {
+	t := reflect.TypeFor[T]()
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("mind output type must be a struct, got %s", t)
+	}
+	return structSchema(t, map[reflect.Type]bool{})
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// schemaField describes one struct field in the derived schema.
+type schemaField struct {
+	name     string
+	index    []int
+	depth    int
+	tagged   bool
+	optional bool
+}
+
+// schemaFields lists the exported, JSON-visible fields of t. Fields of
+// embedded structs without a json name are promoted, and name conflicts
+// are resolved as encoding/json does.
+func schemaFields(t reflect.Type) []schemaField {
+	var all []schemaField
+	collectSchemaFields(t, nil, false, map[reflect.Type]bool{}, &all)
+
+	byName := make(map[string][]schemaField)
+	var order []string
+	for _, f := range all {
+		if _, ok := byName[f.name]; !ok {
+			order = append(order, f.name)
+		}
+		byName[f.name] = append(byName[f.name], f)
+	}
+
+	var fields []schemaField
+	for _, name := range order {
+		if f, ok := dominantField(byName[name]); ok {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+func collectSchemaFields(t reflect.Type, index []int, optional bool, visited map[reflect.Type]bool, out *[]schemaField) {
+	// An embedded type seen before is skipped, as encoding/json does.
+	if visited[t] {
+		return
+	}
+	visited[t] = true
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		parts := strings.Split(tag, ",")
+		name := parts[0]
+
+		ft := f.Type
+		if f.Anonymous {
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if !f.IsExported() && ft.Kind() != reflect.Struct {
+				continue
+			}
+			if name == "" && ft.Kind() == reflect.Struct && ft != timeType {
+				// Fields behind an embedded pointer are absent when it is nil.
+				collectSchemaFields(ft, append(slices.Clone(index), i), optional || f.Type.Kind() == reflect.Ptr, visited, out)
+				continue
+			}
+		} else if !f.IsExported() {
+			continue
+		}
+
+		sf := schemaField{
+			name:     f.Name,
+			index:    append(slices.Clone(index), i),
+			depth:    len(index),
+			tagged:   name != "",
+			optional: optional || f.Type.Kind() == reflect.Ptr,
+		}
+		if name != "" {
+			sf.name = name
+		}
+		for _, opt := range parts[1:] {
+			if opt == "omitempty" || opt == "omitzero" {
+				sf.optional = true
+			}
+		}
+		*out = append(*out, sf)
+	}
+}
+
+// dominantField picks the field encoding/json uses among fields sharing
+// a name: the shallowest one, preferring a tagged field at that depth.
+// Ambiguous names are dropped.
+func dominantField(fields []schemaField) (schemaField, bool) {
+	depth := slices.MinFunc(fields, func(a, b schemaField) int { return a.depth - b.depth }).depth
+	var candidates []schemaField
+	for _, f := range fields {
+		if f.depth == depth {
+			candidates = append(candidates, f)
+		}
+	}
+	if len(candidates) == 1 {
+		return candidates[0], true
+	}
+	var tagged []schemaField
+	for _, f := range candidates {
+		if f.tagged {
+			tagged = append(tagged, f)
+		}
+	}
+	if len(tagged) == 1 {
+		return tagged[0], true
+	}
+	return schemaField{}, false
+}
+
+// structSchema returns the field map for a struct type. visiting holds
+// the struct types being expanded, to reject recursive types.
+func structSchema(t reflect.Type, visiting map[reflect.Type]bool) (map[string]any, error) {
+	if visiting[t] {
+		return nil, fmt.Errorf("recursive type %s is not supported", t)
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	props := make(map[string]any)
+	for _, f := range schemaFields(t) {
+		sf := t.FieldByIndex(f.index)
+		def, err := typeSchema(sf.Type, visiting)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", sf.Name, err)
+		}
+		if desc := sf.Tag.Get("description"); desc != "" {
+			def["description"] = desc
+		}
+		props[f.name] = def
+	}
+	return props, nil
+}
+
+// typeSchema returns the schema definition for a single Go type.
+func typeSchema(t reflect.Type, visiting map[reflect.Type]bool) (map[string]any, error) {
+	typ, err := schemaType(t)
+	if err != nil {
+		return nil, err
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	def := map[string]any{"type": typ}
+	switch {
+	case t == timeType:
+		def["format"] = "date-time"
+	case typ == "array":
+		items, err := typeSchema(t.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		def["items"] = items
+	case t.Kind() == reflect.Struct:
+		props, err := structSchema(t, visiting)
+		if err != nil {
+			return nil, err
+		}
+		def["properties"] = props
+	}
+	return def, nil
+}
+
+// schemaType returns the schema type name of t without expanding
+// element or field types.
+func schemaType(t reflect.Type) (string, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return "string", nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "string", nil
+	case reflect.Bool:
+		return "boolean", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer", nil
+	case reflect.Float32, reflect.Float64:
+		return "number", nil
+	case reflect.Slice, reflect.Array:
+		return "array", nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return "", fmt.Errorf("unsupported map key type %s", t.Key())
+		}
+		return "object", nil
+	case reflect.Struct:
+		return "object", nil
+	}
+	return "", fmt.Errorf("unsupported type %s", t)
+}
+
+// decodeMindResult validates result against T and decodes it.
+func decodeMindResult[T any](result map[string]any) (*T, error) {
+	t := reflect.TypeFor[T]()
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if err := checkStruct(t, result, ""); err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal mind result: %w", err)
+	}
+	var out T
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, fmt.Errorf("failed to decode mind result: %w", err)
+	}
+	return &out, nil
+}
+
+func checkStruct(t reflect.Type, obj map[string]any, path string) error {
+	for _, f := range schemaFields(t) {
+		fieldPath := joinPath(path, f.name)
+		v, ok := obj[f.name]
+		if !ok || v == nil {
+			if f.optional {
+				continue
+			}
+			expected, _ := schemaType(t.FieldByIndex(f.index).Type)
+			return &SchemaMismatchError{Path: fieldPath, Expected: expected, Got: "missing"}
+		}
+		if err := checkValue(t.FieldByIndex(f.index).Type, v, fieldPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkValue(t reflect.Type, v any, path string) error {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if v == nil {
+		return nil
+	}
+
+	expected, err := schemaType(t)
+	if err != nil {
+		return err
+	}
+	mismatch := &SchemaMismatchError{Path: path, Expected: expected, Got: jsonTypeName(v)}
+
+	switch expected {
+	case "string":
+		s, ok := v.(string)
+		if !ok {
+			return mismatch
+		}
+		if t == timeType {
+			if _, err := time.Parse(time.RFC3339, s); err != nil {
+				mismatch.Got = "non-RFC3339 string"
+				return mismatch
+			}
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return mismatch
+		}
+	case "integer":
+		n, ok := v.(float64)
+		if !ok || n != float64(int64(n)) {
+			return mismatch
+		}
+	case "number":
+		if _, ok := v.(float64); !ok {
+			return mismatch
+		}
+	case "array":
+		items, ok := v.([]any)
+		if !ok {
+			return mismatch
+		}
+		for i, item := range items {
+			if err := checkValue(t.Elem(), item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case "object":
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return mismatch
+		}
+		if t.Kind() == reflect.Struct {
+			return checkStruct(t, obj, path)
+		}
+		for key, item := range obj {
+			if err := checkValue(t.Elem(), item, joinPath(path, key)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// jsonTypeName names the JSON type of a decoded value.
+func jsonTypeName(v any) string {
+	switch n := v.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		if n == float64(int64(n)) {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	case nil:
+		return "null"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,141 @@
+package gomind
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type mindPerson struct {
+	Name   string   `json:"name" description:"Full name"`
+	Age    int      `json:"age"`
+	Skills []string `json:"skills"`
+	Email  *string  `json:"email"`
+}
+
+type mindPeople struct {
+	People []mindPerson `json:"people" description:"Everyone at the company"`
+	Total  int          `json:"total,omitempty"`
+}
+
+func TestOutputSchemaFor(t *testing.T) {
+	schema, err := OutputSchemaFor[mindPeople]()
+	if err != nil {
+		t.Fatalf("OutputSchemaFor: %v", err)
+	}
+	raw, _ := json.Marshal(schema)
+	want := `{"people":{"description":"Everyone at the company","items":{"properties":{"age":{"type":"integer"},"email":{"type":"string"},"name":{"description":"Full name","type":"string"},"skills":{"items":{"type":"string"},"type":"array"}},"type":"object"},"type":"array"},"total":{"type":"integer"}}`
+	if string(raw) != want {
+		t.Errorf("unexpected schema:\n%s\nwant:\n%s", raw, want)
+	}
+
+	if _, err := OutputSchemaFor[string](); err == nil {
+		t.Errorf("expected non-struct type to be rejected")
+	}
+}
+
+type mindNode struct {
+	Name     string     `json:"name"`
+	Children []mindNode `json:"children"`
+}
+
+type mindAudit struct {
+	CreatedBy string `json:"created_by"`
+	Name      string `json:"audit_name"`
+}
+
+type mindEmbedded struct {
+	mindAudit
+	*mindPerson
+	Name string `json:"name"`
+}
+
+func TestOutputSchemaForRecursiveAndEmbedded(t *testing.T) {
+	if _, err := OutputSchemaFor[mindNode](); err == nil {
+		t.Error("expected recursive type to be rejected")
+	}
+	if _, _, err := MindAs[mindNode](context.Background(), nil, "x", nil); err == nil {
+		t.Error("expected MindAs to reject a recursive type")
+	}
+
+	schema, err := OutputSchemaFor[mindEmbedded]()
+	if err != nil {
+		t.Fatalf("OutputSchemaFor: %v", err)
+	}
+	raw, _ := json.Marshal(schema)
+	want := `{"age":{"type":"integer"},"audit_name":{"type":"string"},"created_by":{"type":"string"},"email":{"type":"string"},"name":{"type":"string"},"skills":{"items":{"type":"string"},"type":"array"}}`
+	if string(raw) != want {
+		t.Errorf("unexpected schema:\n%s\nwant:\n%s", raw, want)
+	}
+
+	// Fields behind the nil embedded pointer are optional.
+	out, err := decodeMindResult[mindEmbedded](map[string]any{"created_by": "ops", "audit_name": "a", "name": "John"})
+	if err != nil {
+		t.Fatalf("decodeMindResult: %v", err)
+	}
+	if out.CreatedBy != "ops" || out.Name != "John" {
+		t.Errorf("unexpected result %+v", out)
+	}
+	var mismatch *SchemaMismatchError
+	if _, err := decodeMindResult[mindEmbedded](map[string]any{"audit_name": "a", "name": "John"}); !errors.As(err, &mismatch) || mismatch.Path != "created_by" {
+		t.Errorf("expected missing created_by, got %v", err)
+	}
+}
+
+func TestMindAs(t *testing.T) {
+	var result string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req MindRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if _, ok := req.OutputSchema["people"]; !ok {
+			t.Errorf("expected derived output_schema, got %v", req.OutputSchema)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"OK","result":{"result":` + result + `,"meta":{"tokens_used":42,"latency_ms":7}}}`))
+	}))
+	defer srv.Close()
+
+	client, err := NewClient("test-key", WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	ctx := context.Background()
+
+	result = `{"people":[{"name":"John","age":41,"skills":["go"]}]}`
+	out, meta, err := MindAs[mindPeople](ctx, client, "Who works at {{company}}?", map[string]string{"company": "Acme"})
+	if err != nil {
+		t.Fatalf("MindAs: %v", err)
+	}
+	if meta.TokensUsed != 42 || len(out.People) != 1 || out.People[0].Age != 41 || out.People[0].Email != nil {
+		t.Errorf("unexpected result %+v meta %+v", out, meta)
+	}
+
+	tests := []struct {
+		result string
+		path   string
+		got    string
+	}{
+		{`{"people":[{"name":"John","age":"41","skills":[]}]}`, "people[0].age", "string"},
+		{`{"people":[{"name":"John","age":41.5,"skills":[]}]}`, "people[0].age", "number"},
+		{`{"people":[{"age":41,"skills":[]}]}`, "people[0].name", "missing"},
+		{`{"people":{"name":"John"}}`, "people", "object"},
+	}
+	for _, tc := range tests {
+		result = tc.result
+		_, meta, err := MindAs[mindPeople](ctx, client, "x", nil)
+		var mismatch *SchemaMismatchError
+		if !errors.As(err, &mismatch) {
+			t.Errorf("%s: expected *SchemaMismatchError, got %v", tc.result, err)
+			continue
+		}
+		if mismatch.Path != tc.path || mismatch.Got != tc.got {
+			t.Errorf("%s: got path=%s got=%s", tc.result, mismatch.Path, mismatch.Got)
+		}
+		if meta.TokensUsed != 42 {
+			t.Errorf("expected meta to be returned with mismatch errors")
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package gomind
 
+import "time"
+
 // APIResponse is the generic response wrapper from Gomind API
 type APIResponse[T any] struct {
 	Status string `json:"status"`
@@ -14,14 +16,22 @@ type Entity struct {
 }
 
 // Fact represents a single fact (triplet) in the knowledge graph
-// This matches the API's FactOutput format
+// This matches the API's FactOutput format. ID is set when the server
+// returns it and is what MoveFactsToCollection expects.
 type Fact struct {
+	ID        string `json:"id,omitempty"`
 	Subject   string `json:"subject"`
 	Predicate string `json:"predicate"`
 	Object    string `json:"object,omitempty"`
 	Value     string `json:"value,omitempty"`
 	Context   string `json:"context,omitempty"`
 	Source    string `json:"source,omitempty"`
+
+	// ValidFrom and ValidTo bound when the fact was true; nil means
+	// unbounded. ObservedAt is when it was recorded.
+	ValidFrom  *time.Time `json:"valid_from,omitempty"`
+	ValidTo    *time.Time `json:"valid_to,omitempty"`
+	ObservedAt *time.Time `json:"observed_at,omitempty"`
 }
 
 // RememberRequest is the request body for the remember endpoint.
@@ -41,11 +51,19 @@ type RememberRequest struct {
 	Context    string  `json:"context,omitempty"`
 	Normalize  bool    `json:"normalize,omitempty"`
 	Collection *string `json:"collection,omitempty"`
+
+	// ValidFrom and ValidTo optionally bound when the fact is true, e.g.
+	// the start and end of a job. ObservedAt is when the fact was
+	// learned.
+	ValidFrom  *time.Time `json:"valid_from,omitempty"`
+	ValidTo    *time.Time `json:"valid_to,omitempty"`
+	ObservedAt *time.Time `json:"observed_at,omitempty"`
 }
 
 // RememberResponse is the response from the remember endpoint
-// Matches API's FactOutput format
+// Matches API's FactOutput format. ID is set when the server returns it.
 type RememberResponse struct {
+	ID        string `json:"id,omitempty"`
 	Subject   string `json:"subject"`
 	Predicate string `json:"predicate"`
 	Object    string `json:"object,omitempty"`
@@ -74,6 +92,11 @@ type RecallRequest struct {
 	FuzzyMatch bool     `json:"fuzzy_match,omitempty"`
 	Limit      int      `json:"limit,omitempty"`
 	Collection *string  `json:"collection,omitempty"`
+
+	// AsOf, when set, returns only facts valid at that time: facts
+	// without a validity interval and facts whose ValidFrom/ValidTo
+	// interval contains it.
+	AsOf *time.Time `json:"as_of,omitempty"`
 }
 
 // RecallResponse is the response from the recall endpoint
@@ -95,6 +118,9 @@ type RecallConnectionsRequest struct {
 // ForgetRequest is the request body for the forget endpoint.
 // See RememberRequest.Collection for the *string semantics.
 type ForgetRequest struct {
+	// ID, when set, forgets only the fact with that ID (see Fact.ID)
+	// rather than every fact with this subject, predicate and object.
+	ID         string  `json:"id,omitempty"`
 	Subject    string  `json:"subject"`
 	Predicate  string  `json:"predicate"`
 	Object     string  `json:"object"`
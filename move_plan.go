@@ -0,0 +1,420 @@
+package gomind
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Defaults for MovePlanRequest.
+const (
+	DefaultMoveBatchSize = 100
+	DefaultMaxMoveFacts  = 1000
+)
+
+// MoveStrategy selects how PlanMove handles entities shared with facts
+// outside the move set, which the server rejects with a 409.
+type MoveStrategy int
+
+const (
+	// MoveReject returns a *SharedEntityError when any entity is shared.
+	MoveReject MoveStrategy = iota
+	// MoveExpand grows the move set to its shared-entity closure: every
+	// fact referencing an entity of a moved fact is moved too.
+	MoveExpand
+	// MoveSplit moves only the facts whose entities are not shared with
+	// facts staying behind and holds the rest back.
+	MoveSplit
+)
+
+// MovePlanRequest describes the facts to move.
+type MovePlanRequest struct {
+	OrgID      string
+	TargetCode string
+
+	// FactIDs lists the facts to move. They are looked up in Source,
+	// which must then name a collection.
+	FactIDs []string
+	// Facts may be given instead of FactIDs, e.g. straight from Recall.
+	// Each fact must carry its ID.
+	Facts []Fact
+
+	// Source is the collection the facts currently live in, and where
+	// shared entities are searched. Nil uses the client default.
+	Source *string
+
+	Strategy MoveStrategy
+
+	// MaxFacts bounds the move set under MoveExpand. Defaults to
+	// DefaultMaxMoveFacts.
+	MaxFacts int
+	// BatchSize is the target number of facts per move request. Facts
+	// sharing entities always move in the same batch, so a batch may be
+	// larger. Defaults to DefaultMoveBatchSize.
+	BatchSize int
+}
+
+// SharedEntity is an entity referenced both by facts in a move set and
+// by facts outside it.
+type SharedEntity struct {
+	Entity         string
+	FactIDs        []string
+	OutsideFactIDs []string
+}
+
+// SharedEntityError reports the shared entities that block a move.
+type SharedEntityError struct {
+	Conflicts []SharedEntity
+}
+
+func (e *SharedEntityError) Error() string {
+	parts := make([]string, len(e.Conflicts))
+	for i, conflict := range e.Conflicts {
+		parts[i] = fmt.Sprintf("%s (%d facts outside the move set)", conflict.Entity, len(conflict.OutsideFactIDs))
+	}
+	return fmt.Sprintf("move would split %d shared entities: %s", len(e.Conflicts), strings.Join(parts, ", "))
+}
+
+// MovePlan is the result of PlanMove.
+type MovePlan struct {
+	OrgID      string
+	TargetCode string
+	Strategy   MoveStrategy
+
+	// Batches lists the fact IDs to move, one move request per batch.
+	Batches [][]string
+	// Added lists facts MoveExpand added to the requested set.
+	Added []string
+	// Held lists requested facts MoveSplit leaves in place.
+	Held []string
+	// Conflicts lists the shared entities of the requested set.
+	Conflicts []SharedEntity
+}
+
+// FactCount returns the number of facts the plan moves.
+func (p *MovePlan) FactCount() int {
+	n := 0
+	for _, batch := range p.Batches {
+		n += len(batch)
+	}
+	return n
+}
+
+// MovePlanResult aggregates the MoveSummary of every executed batch.
+type MovePlanResult struct {
+	MoveSummary
+	// Batches counts the batches moved successfully.
+	Batches int
+	// Held lists the facts the plan left in place.
+	Held []string
+}
+
+// PlanMove works out how to move facts without splitting entities
+// between collections. It discovers, through RecallConnections, every
+// fact in Source that references an entity of the move set, and applies
+// req.Strategy to the conflicts. Under MoveReject a conflicting plan is
+// returned together with a *SharedEntityError.
+func (c *Client) PlanMove(ctx context.Context, req MovePlanRequest) (*MovePlan, error) {
+	if req.MaxFacts <= 0 {
+		req.MaxFacts = DefaultMaxMoveFacts
+	}
+	if req.BatchSize <= 0 {
+		req.BatchSize = DefaultMoveBatchSize
+	}
+
+	p := &movePlanner{client: c, ctx: ctx, source: c.resolveCollection(req.Source)}
+	requested, err := p.requestedFacts(req)
+	if err != nil {
+		return nil, err
+	}
+
+	moving := make(map[string]bool)
+	for _, id := range requested {
+		moving[id] = true
+	}
+	conflicts, err := p.conflicts(moving)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &MovePlan{OrgID: req.OrgID, TargetCode: req.TargetCode, Strategy: req.Strategy, Conflicts: conflicts}
+	order := requested
+	switch req.Strategy {
+	case MoveReject:
+		if len(conflicts) > 0 {
+			plan.Batches = p.batches(order, moving, req.BatchSize)
+			return plan, &SharedEntityError{Conflicts: conflicts}
+		}
+
+	case MoveExpand:
+		for current := conflicts; len(current) > 0; {
+			var added []string
+			for _, conflict := range current {
+				for _, id := range conflict.OutsideFactIDs {
+					if !moving[id] {
+						moving[id] = true
+						added = append(added, id)
+					}
+				}
+			}
+			slices.Sort(added)
+			plan.Added = append(plan.Added, added...)
+			if len(moving) > req.MaxFacts {
+				return nil, fmt.Errorf("shared-entity closure exceeds %d facts: %w", req.MaxFacts, &SharedEntityError{Conflicts: current})
+			}
+			if current, err = p.conflicts(moving); err != nil {
+				return nil, err
+			}
+		}
+		order = append(slices.Clone(requested), plan.Added...)
+
+	case MoveSplit:
+		for {
+			blocked := make(map[string]bool)
+			current, err := p.conflicts(moving)
+			if err != nil {
+				return nil, err
+			}
+			for _, conflict := range current {
+				blocked[conflict.Entity] = true
+			}
+			if len(blocked) == 0 {
+				break
+			}
+			for _, id := range requested {
+				if moving[id] && slices.ContainsFunc(entitiesOf(p.facts[id]), func(e string) bool { return blocked[e] }) {
+					delete(moving, id)
+					plan.Held = append(plan.Held, id)
+				}
+			}
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown move strategy %d", req.Strategy)
+	}
+
+	plan.Batches = p.batches(order, moving, req.BatchSize)
+	c.logger.Info("Gomind PlanMove success",
+		"target", req.TargetCode,
+		"facts", plan.FactCount(),
+		"batches", len(plan.Batches),
+		"conflicts", len(conflicts),
+	)
+	return plan, nil
+}
+
+// ExecuteMovePlan moves the plan's batches in order. On failure it
+// returns the totals of the batches already moved with the error.
+func (c *Client) ExecuteMovePlan(ctx context.Context, plan *MovePlan) (*MovePlanResult, error) {
+	result := &MovePlanResult{Held: plan.Held}
+	for i, batch := range plan.Batches {
+		summary, err := c.MoveFactsToCollectionByCode(ctx, plan.OrgID, plan.TargetCode, batch)
+		if err != nil {
+			return result, fmt.Errorf("move batch %d of %d failed: %w", i+1, len(plan.Batches), err)
+		}
+		result.MovedFacts += summary.MovedFacts
+		result.Batches++
+	}
+	return result, nil
+}
+
+// movePlanner caches the facts and entity connections seen while
+// planning.
+type movePlanner struct {
+	client *Client
+	ctx    context.Context
+	source *string
+
+	facts       map[string]Fact            // by ID
+	entityFacts map[string]map[string]bool // entity -> fact IDs
+}
+
+func entitiesOf(f Fact) []string {
+	if f.Object != "" && f.Object != f.Subject {
+		return []string{f.Subject, f.Object}
+	}
+	return []string{f.Subject}
+}
+
+func (p *movePlanner) addFact(f Fact) error {
+	if f.ID == "" {
+		return fmt.Errorf("fact %s %s %s has no id", f.Subject, f.Predicate, getObjectValue(f))
+	}
+	if p.facts == nil {
+		p.facts = make(map[string]Fact)
+	}
+	p.facts[f.ID] = f
+	return nil
+}
+
+// requestedFacts loads the requested facts and returns their IDs in
+// request order.
+func (p *movePlanner) requestedFacts(req MovePlanRequest) ([]string, error) {
+	var ids []string
+	for _, f := range req.Facts {
+		if err := p.addFact(f); err != nil {
+			return nil, err
+		}
+		ids = append(ids, f.ID)
+	}
+	if len(req.FactIDs) > 0 {
+		if p.source == nil || canonicalCollection(*p.source) == "" {
+			return nil, fmt.Errorf("looking up fact ids requires a source collection; pass Facts instead")
+		}
+		wanted := make(map[string]bool)
+		for _, id := range req.FactIDs {
+			wanted[id] = true
+		}
+		for fact, err := range p.client.AllFacts(p.ctx, req.OrgID, *p.source, ListFactsOptions{}) {
+			if err != nil {
+				return nil, err
+			}
+			if wanted[fact.ID] {
+				if err := p.addFact(fact); err != nil {
+					return nil, err
+				}
+			}
+		}
+		var missing []string
+		for _, id := range req.FactIDs {
+			if _, ok := p.facts[id]; !ok {
+				missing = append(missing, id)
+			}
+		}
+		if len(missing) > 0 {
+			return nil, fmt.Errorf("facts not found in collection %q: %s", *p.source, strings.Join(missing, ", "))
+		}
+		ids = append(ids, req.FactIDs...)
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("at least one fact is required")
+	}
+
+	seen := make(map[string]bool)
+	return slices.DeleteFunc(ids, func(id string) bool {
+		dup := seen[id]
+		seen[id] = true
+		return dup
+	}), nil
+}
+
+// connections returns the IDs of every fact referencing entity.
+func (p *movePlanner) connections(entity string) (map[string]bool, error) {
+	if ids, ok := p.entityFacts[entity]; ok {
+		return ids, nil
+	}
+	resp, err := p.client.RecallConnectionsWithOptions(p.ctx, RecallConnectionsRequest{
+		Entity:     entity,
+		Depth:      1,
+		Collection: p.source,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]bool)
+	for _, f := range resp.Facts {
+		if !slices.Contains(entitiesOf(f), entity) {
+			continue
+		}
+		if err := p.addFact(f); err != nil {
+			return nil, err
+		}
+		ids[f.ID] = true
+	}
+	if p.entityFacts == nil {
+		p.entityFacts = make(map[string]map[string]bool)
+	}
+	p.entityFacts[entity] = ids
+	return ids, nil
+}
+
+// conflicts lists the entities of the moving facts that are also
+// referenced by facts outside the set, sorted by entity.
+func (p *movePlanner) conflicts(moving map[string]bool) ([]SharedEntity, error) {
+	byEntity := make(map[string][]string)
+	for id := range moving {
+		for _, e := range entitiesOf(p.facts[id]) {
+			byEntity[e] = append(byEntity[e], id)
+		}
+	}
+
+	var conflicts []SharedEntity
+	for entity, inside := range byEntity {
+		ids, err := p.connections(entity)
+		if err != nil {
+			return nil, err
+		}
+		var outside []string
+		for id := range ids {
+			if !moving[id] {
+				outside = append(outside, id)
+			}
+		}
+		if len(outside) == 0 {
+			continue
+		}
+		slices.Sort(inside)
+		slices.Sort(outside)
+		conflicts = append(conflicts, SharedEntity{Entity: entity, FactIDs: inside, OutsideFactIDs: outside})
+	}
+	slices.SortFunc(conflicts, func(a, b SharedEntity) int { return strings.Compare(a.Entity, b.Entity) })
+	return conflicts, nil
+}
+
+// batches groups the moving facts into batches of about size facts.
+// Facts connected through shared entities stay in one batch, so each
+// batch can be moved on its own without a 409.
+func (p *movePlanner) batches(order []string, moving map[string]bool, size int) [][]string {
+	parent := make(map[string]string)
+	var find func(string) string
+	find = func(x string) string {
+		if parent[x] == "" || parent[x] == x {
+			return x
+		}
+		parent[x] = find(parent[x])
+		return parent[x]
+	}
+	owner := make(map[string]string) // entity -> first fact referencing it
+	for _, id := range order {
+		if !moving[id] {
+			continue
+		}
+		for _, e := range entitiesOf(p.facts[id]) {
+			if first, ok := owner[e]; ok {
+				parent[find(id)] = find(first)
+			} else {
+				owner[e] = id
+			}
+		}
+	}
+
+	var roots []string
+	groups := make(map[string][]string)
+	for _, id := range order {
+		if !moving[id] {
+			continue
+		}
+		root := find(id)
+		if _, ok := groups[root]; !ok {
+			roots = append(roots, root)
+		}
+		groups[root] = append(groups[root], id)
+	}
+
+	var batches [][]string
+	var current []string
+	for _, root := range roots {
+		group := groups[root]
+		if len(current) > 0 && len(current)+len(group) > size {
+			batches = append(batches, current)
+			current = nil
+		}
+		current = append(current, group...)
+	}
+	if len(current) > 0 {
+		batches = append(batches, current)
+	}
+	return batches
+}
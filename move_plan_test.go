@@ -0,0 +1,155 @@
+package gomind
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+var moveTestFacts = []Fact{
+	{ID: "f1", Subject: "John", Predicate: "works_at", Object: "Acme"},
+	{ID: "f2", Subject: "Jane", Predicate: "works_at", Object: "Acme"},
+	{ID: "f3", Subject: "John", Predicate: "lives_in", Object: "Berlin"},
+	{ID: "f4", Subject: "Bob", Predicate: "likes", Object: "Tea"},
+	{ID: "f5", Subject: "Carol", Predicate: "knows", Object: "Bob"},
+	{ID: "f6", Subject: "Dave", Predicate: "owns", Object: "Boat"},
+}
+
+// newMoveServer serves recall_connections over moveTestFacts and
+// records the fact IDs of every move request.
+func newMoveServer(t *testing.T, moves *[][]string) *Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		write := func(v any) {
+			json.NewEncoder(w).Encode(map[string]any{"status": "ok", "result": v})
+		}
+		switch r.URL.Path {
+		case "/v1/recall_connections":
+			var req RecallConnectionsRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Collection == nil || *req.Collection != "inbox" {
+				t.Errorf("expected connections to be searched in the source collection, got %v", req.Collection)
+			}
+			var facts []Fact
+			for _, f := range moveTestFacts {
+				if f.Subject == req.Entity || f.Object == req.Entity {
+					facts = append(facts, f)
+				}
+			}
+			write(RecallResponse{Facts: facts, Count: len(facts)})
+		case "/v1/orgs/org1/collections/":
+			write([]Collection{{ID: "id-archive", Code: "archive"}})
+		case "/v1/orgs/org1/collections/id-archive/move":
+			var req moveFactsRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			*moves = append(*moves, req.FactIDs)
+			write(MoveSummary{MovedFacts: len(req.FactIDs)})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := NewClient("test-key", WithBaseURL(srv.URL), WithCollection("inbox"))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
+
+func moveRequest(strategy MoveStrategy, ids ...string) MovePlanRequest {
+	req := MovePlanRequest{OrgID: "org1", TargetCode: "archive", Strategy: strategy, BatchSize: 2}
+	for _, f := range moveTestFacts {
+		if slices.Contains(ids, f.ID) {
+			req.Facts = append(req.Facts, f)
+		}
+	}
+	return req
+}
+
+func TestPlanMoveReject(t *testing.T) {
+	var moves [][]string
+	client := newMoveServer(t, &moves)
+
+	_, err := client.PlanMove(context.Background(), moveRequest(MoveReject, "f1", "f4"))
+	var shared *SharedEntityError
+	if !errors.As(err, &shared) {
+		t.Fatalf("expected *SharedEntityError, got %v", err)
+	}
+	var entities []string
+	for _, c := range shared.Conflicts {
+		entities = append(entities, c.Entity)
+	}
+	if !reflect.DeepEqual(entities, []string{"Acme", "Bob", "John"}) {
+		t.Errorf("unexpected conflicts %v", entities)
+	}
+	if got := shared.Conflicts[2].OutsideFactIDs; !reflect.DeepEqual(got, []string{"f3"}) {
+		t.Errorf("unexpected facts sharing John %v", got)
+	}
+
+	plan, err := client.PlanMove(context.Background(), moveRequest(MoveReject, "f6"))
+	if err != nil || !reflect.DeepEqual(plan.Batches, [][]string{{"f6"}}) {
+		t.Errorf("expected an unshared fact to move as is, got %+v, %v", plan, err)
+	}
+}
+
+func TestPlanMoveExpand(t *testing.T) {
+	var moves [][]string
+	client := newMoveServer(t, &moves)
+	ctx := context.Background()
+
+	plan, err := client.PlanMove(ctx, moveRequest(MoveExpand, "f1", "f4"))
+	if err != nil {
+		t.Fatalf("PlanMove: %v", err)
+	}
+	if !reflect.DeepEqual(plan.Added, []string{"f2", "f3", "f5"}) {
+		t.Errorf("unexpected closure %v", plan.Added)
+	}
+	want := [][]string{{"f1", "f2", "f3"}, {"f4", "f5"}}
+	if !reflect.DeepEqual(plan.Batches, want) {
+		t.Errorf("unexpected batches %v, want %v", plan.Batches, want)
+	}
+
+	result, err := client.ExecuteMovePlan(ctx, plan)
+	if err != nil {
+		t.Fatalf("ExecuteMovePlan: %v", err)
+	}
+	if result.MovedFacts != 5 || result.Batches != 2 || !reflect.DeepEqual(moves, want) {
+		t.Errorf("unexpected result %+v, moves %v", result, moves)
+	}
+
+	req := moveRequest(MoveExpand, "f1")
+	req.MaxFacts = 2
+	if _, err := client.PlanMove(ctx, req); !errors.As(err, new(*SharedEntityError)) {
+		t.Errorf("expected the closure cap to fail with the conflicts, got %v", err)
+	}
+}
+
+func TestPlanMoveSplit(t *testing.T) {
+	var moves [][]string
+	client := newMoveServer(t, &moves)
+
+	plan, err := client.PlanMove(context.Background(), moveRequest(MoveSplit, "f1", "f4", "f6"))
+	if err != nil {
+		t.Fatalf("PlanMove: %v", err)
+	}
+	if !reflect.DeepEqual(plan.Held, []string{"f1", "f4"}) || !reflect.DeepEqual(plan.Batches, [][]string{{"f6"}}) {
+		t.Errorf("unexpected split: held %v, batches %v", plan.Held, plan.Batches)
+	}
+}
+
+func TestPlanMoveByIDRequiresSource(t *testing.T) {
+	client, err := NewClient("test-key", WithBaseURL("http://127.0.0.1:0"))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	_, err = client.PlanMove(context.Background(), MovePlanRequest{OrgID: "org1", TargetCode: "archive", FactIDs: []string{"f1"}})
+	if err == nil {
+		t.Error("expected fact id lookup without a source collection to fail")
+	}
+}
@@ -0,0 +1,99 @@
+package gomind
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// MoveFactsMatching moves every fact matched by query into the
+// collection with code targetCode. query is run repeatedly with
+// RecallWithOptions, moving the matching facts of each page until a page
+// has none left to move; query.Limit sets the page size and defaults to
+// DefaultMoveBatchSize. query.Collection selects the source collection
+// as usual.
+//
+// Recall is a similarity search, so each page is filtered before moving:
+// query.Query must equal the subject, query.Predicate (or one of
+// query.Predicates) the predicate and query.RelatedTo the subject or
+// object. At least one of these filters is required. EntityType cannot
+// be checked on the client and only narrows the search.
+//
+// The matches of each page are moved together as is, so the server
+// rejects pages whose entities are shared with facts left behind; use
+// PlanMove for those.
+func (c *Client) MoveFactsMatching(ctx context.Context, orgID, targetCode string, query RecallRequest) (*MoveSummary, error) {
+	if strings.TrimSpace(orgID) == "" {
+		return nil, fmt.Errorf("org id is required")
+	}
+	if query.Query == "" && query.Predicate == "" && len(query.Predicates) == 0 && query.RelatedTo == "" {
+		return nil, fmt.Errorf("a query, predicate or related_to filter is required")
+	}
+	if query.Limit <= 0 {
+		query.Limit = DefaultMoveBatchSize
+	}
+
+	total := &MoveSummary{}
+	moved := make(map[string]bool)
+	for {
+		resp, err := c.RecallWithOptions(ctx, query)
+		if err != nil {
+			return total, err
+		}
+
+		var ids []string
+		for _, f := range resp.Facts {
+			if !matchesMoveQuery(f, query) {
+				continue
+			}
+			if f.ID == "" {
+				return total, fmt.Errorf("recall returned fact %s %s %s without an id", f.Subject, f.Predicate, getObjectValue(f))
+			}
+			if !moved[f.ID] {
+				ids = append(ids, f.ID)
+			}
+		}
+		// Stop once a page has nothing left to move: only similar facts,
+		// or facts already moved when the target is also searched.
+		if len(ids) == 0 {
+			break
+		}
+
+		summary, err := c.MoveFactsToCollectionByCode(ctx, orgID, targetCode, ids)
+		if err != nil {
+			return total, err
+		}
+		total.MovedFacts += summary.MovedFacts
+		for _, id := range ids {
+			moved[id] = true
+		}
+		if len(resp.Facts) < query.Limit {
+			break
+		}
+	}
+
+	c.logger.Info("Gomind MoveFactsMatching success",
+		"orgID", orgID,
+		"target", targetCode,
+		"movedFacts", total.MovedFacts,
+	)
+	return total, nil
+}
+
+// matchesMoveQuery reports whether f satisfies the exact filters of query.
+func matchesMoveQuery(f Fact, query RecallRequest) bool {
+	if query.Query != "" && f.Subject != query.Query {
+		return false
+	}
+	if query.Predicate != "" && f.Predicate != query.Predicate {
+		return false
+	}
+	if len(query.Predicates) > 0 && !slices.Contains(query.Predicates, f.Predicate) {
+		return false
+	}
+	if query.RelatedTo != "" && f.Subject != query.RelatedTo && f.Object != query.RelatedTo {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,151 @@
+package gomind
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// newRecallMoveServer serves recall over facts in the "inbox" collection
+// and moves facts out of it. Like the real similarity search, recall
+// returns the first facts of the collection whether or not they match.
+// With sticky set, moved facts keep being returned by recall.
+func newRecallMoveServer(t *testing.T, facts []Fact, sticky bool, moves *[][]string) *Client {
+	t.Helper()
+	var mu sync.Mutex
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		write := func(v any) {
+			json.NewEncoder(w).Encode(map[string]any{"status": "ok", "result": v})
+		}
+		switch r.URL.Path {
+		case "/v1/recall":
+			var req RecallRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			var page []Fact
+			for _, f := range facts {
+				if len(page) < req.Limit {
+					page = append(page, f)
+				}
+			}
+			write(RecallResponse{Facts: page, Count: len(page)})
+		case "/v1/orgs/org1/collections/":
+			write([]Collection{{ID: "id-archive", Code: "archive"}})
+		case "/v1/orgs/org1/collections/id-archive/move":
+			var req moveFactsRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			*moves = append(*moves, req.FactIDs)
+			if !sticky {
+				facts = slices.DeleteFunc(facts, func(f Fact) bool { return slices.Contains(req.FactIDs, f.ID) })
+			}
+			write(MoveSummary{MovedFacts: len(req.FactIDs)})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := NewClient("test-key", WithBaseURL(srv.URL), WithCollection("inbox"))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
+
+func TestMoveFactsMatching(t *testing.T) {
+	var facts []Fact
+	for i := range 5 {
+		facts = append(facts, Fact{ID: fmt.Sprintf("f%d", i), Subject: fmt.Sprintf("ticket-%d", i), Predicate: "status", Object: "closed"})
+	}
+	facts = append(facts, Fact{ID: "other", Subject: "ticket-9", Predicate: "owner", Object: "Jane"})
+
+	var moves [][]string
+	client := newRecallMoveServer(t, facts, false, &moves)
+
+	summary, err := client.MoveFactsMatching(context.Background(), "org1", "archive", RecallRequest{Predicate: "status", Limit: 2})
+	if err != nil {
+		t.Fatalf("MoveFactsMatching: %v", err)
+	}
+	if summary.MovedFacts != 5 || len(moves) != 3 {
+		t.Errorf("expected 5 facts in 3 batches, got %+v in %v", summary, moves)
+	}
+	for _, batch := range moves {
+		if slices.Contains(batch, "other") {
+			t.Errorf("moved a fact not matching the query: %v", batch)
+		}
+	}
+}
+
+// TestMoveFactsMatchingStops verifies the loop ends when recall keeps
+// returning facts that were already moved.
+func TestMoveFactsMatchingStops(t *testing.T) {
+	facts := []Fact{
+		{ID: "f1", Subject: "a", Predicate: "status", Object: "closed"},
+		{ID: "f2", Subject: "b", Predicate: "status", Object: "closed"},
+	}
+	var moves [][]string
+	client := newRecallMoveServer(t, facts, true, &moves)
+
+	summary, err := client.MoveFactsMatching(context.Background(), "org1", "archive", RecallRequest{Predicate: "status", Limit: 2})
+	if err != nil {
+		t.Fatalf("MoveFactsMatching: %v", err)
+	}
+	if summary.MovedFacts != 2 || len(moves) != 1 {
+		t.Errorf("expected a single batch, got %+v in %v", summary, moves)
+	}
+}
+
+func TestMoveFactsMatchingFiltersSimilarFacts(t *testing.T) {
+	facts := []Fact{
+		{ID: "f1", Subject: "ticket-10", Predicate: "status", Object: "closed"},
+		{ID: "f2", Subject: "ticket-1", Predicate: "status", Object: "closed"},
+		{ID: "f3", Subject: "ticket-1", Predicate: "status_note", Object: "done"},
+		{ID: "f4", Subject: "Ticket-1", Predicate: "status", Object: "open"},
+	}
+	var moves [][]string
+	client := newRecallMoveServer(t, facts, false, &moves)
+	ctx := context.Background()
+
+	summary, err := client.MoveFactsMatching(ctx, "org1", "archive", RecallRequest{Query: "ticket-1", Predicate: "status", Limit: 2})
+	if err != nil {
+		t.Fatalf("MoveFactsMatching: %v", err)
+	}
+	if summary.MovedFacts != 1 || len(moves) != 1 || !slices.Equal(moves[0], []string{"f2"}) {
+		t.Errorf("expected only f2 to move, got %+v in %v", summary, moves)
+	}
+
+	if _, err := client.MoveFactsMatching(ctx, "org1", "archive", RecallRequest{Limit: 2}); err == nil {
+		t.Error("expected a query without filters to be rejected")
+	}
+	if len(moves) != 1 {
+		t.Errorf("unexpected moves %v", moves)
+	}
+}
+
+func TestMoveFactsMatchingRequiresIDs(t *testing.T) {
+	var moves [][]string
+	client := newRecallMoveServer(t, []Fact{{Subject: "a", Predicate: "status", Object: "closed"}}, false, &moves)
+
+	if _, err := client.MoveFactsMatching(context.Background(), "org1", "archive", RecallRequest{Predicate: "status"}); err == nil {
+		t.Error("expected facts without ids to be rejected")
+	}
+	if len(moves) != 0 {
+		t.Errorf("expected no move, got %v", moves)
+	}
+}
+
+func TestRememberResponseID(t *testing.T) {
+	var resp RememberResponse
+	if err := json.Unmarshal([]byte(`{"id":"0x1f","subject":"John","predicate":"works_at","object":"Acme"}`), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if resp.ID != "0x1f" {
+		t.Errorf("expected fact id, got %+v", resp)
+	}
+}
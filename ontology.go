@@ -0,0 +1,350 @@
+package gomind
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/ingate/gomind-go-sdk/tools"
+)
+
+// ErrOntologyViolation is wrapped by every *OntologyError.
+var ErrOntologyViolation = errors.New("fact violates ontology")
+
+// Cardinality says how many objects a subject may have for a predicate.
+type Cardinality int
+
+const (
+	// Multi allows any number of objects. It is the default.
+	Multi Cardinality = iota
+	// Single allows one object per subject.
+	Single
+)
+
+// PredicateDef declares a predicate of an Ontology.
+type PredicateDef struct {
+	// Name is the canonical predicate, e.g. "works_at".
+	Name        string
+	Description string
+
+	// Aliases are rewritten to Name, e.g. "employed_by".
+	Aliases []string
+
+	// Inverse names the predicate meaning the same relation in the
+	// other direction, e.g. "employs" for "works_at". Facts using an
+	// inverse that is not itself declared are flipped, so "Acme employs
+	// John" is stored as "John works_at Acme".
+	Inverse string
+
+	Cardinality Cardinality
+
+	// SubjectTypes and ObjectTypes list the entity types allowed on
+	// either side. Empty means any type.
+	SubjectTypes []string
+	ObjectTypes  []string
+}
+
+// OntologyError is returned when a fact is rejected by the client's
+// Ontology. It unwraps to ErrOntologyViolation.
+type OntologyError struct {
+	Fact RememberRequest
+	// Index is the position of the fact in a RememberMany request, or
+	// -1 for Remember.
+	Index  int
+	Reason string
+
+	kind violation
+}
+
+// violation classifies an OntologyError for the hint given to models.
+type violation int
+
+const (
+	violationPredicate violation = iota
+	violationCardinality
+	violationType
+)
+
+func (e *OntologyError) Error() string {
+	where := ""
+	if e.Index >= 0 {
+		where = fmt.Sprintf(" (fact %d)", e.Index)
+	}
+	return fmt.Sprintf("fact %q %q %q rejected%s: %s", e.Fact.Subject, e.Fact.Predicate, e.Fact.Object, where, e.Reason)
+}
+
+func (e *OntologyError) Unwrap() error { return ErrOntologyViolation }
+
+// hint tells a model how to fix the rejected fact.
+func (e *OntologyError) hint() string {
+	switch e.kind {
+	case violationCardinality:
+		return "Remember only one value for this predicate and retry"
+	case violationType:
+		return "Use a predicate whose entity types match (see the tool description) and retry"
+	}
+	return "Use a predicate from the tool description and retry"
+}
+
+// typePredicates are the predicates that declare an entity's type.
+var typePredicates = []string{"type", "is_a", "instance_of"}
+
+// Ontology is a registry of allowed predicates. Install it with
+// WithOntology to normalise facts before Remember and RememberMany (and
+// therefore the remember tools) send them, and to list the vocabulary
+// in the remember tool descriptions.
+//
+// Normalisation matches predicates case- and style-insensitively
+// ("worksAt", "Works-At" and "works_at" are the same), rewrites aliases
+// and flips undeclared inverses. Validation then checks cardinality
+// within a request and the subject and object types.
+//
+// Entity types are known from type facts ("type", "is_a",
+// "instance_of") in the same request and from TypeOf; a fact whose
+// entity types are unknown is not type-checked. Type facts are always
+// allowed, even in Strict mode, unless one of them is declared.
+type Ontology struct {
+	// Strict rejects predicates that are not declared. Otherwise they
+	// pass through unchanged.
+	Strict bool
+
+	// TypeOf, when set, returns the type of an entity, or "" if unknown.
+	TypeOf func(entity string) string
+
+	// UpsertSingle makes Remember and RememberMany replace the stored
+	// values of Single predicates, as Upsert does, instead of adding to
+	// them. The remember tools only do so when the client's
+	// ToolPolicy allows forget in the target collections.
+	UpsertSingle bool
+
+	defs     []*PredicateDef
+	byKey    map[string]*PredicateDef
+	inverses map[string]*PredicateDef
+}
+
+// NewOntology returns an ontology declaring defs. Names, aliases and
+// inverses must not clash.
+func NewOntology(defs ...PredicateDef) (*Ontology, error) {
+	o := &Ontology{
+		byKey:    make(map[string]*PredicateDef),
+		inverses: make(map[string]*PredicateDef),
+	}
+	for _, def := range defs {
+		if err := o.add(def); err != nil {
+			return nil, err
+		}
+	}
+	// Inverses that are declared predicates in their own right are not
+	// rewritten.
+	for key := range o.inverses {
+		if _, ok := o.byKey[key]; ok {
+			delete(o.inverses, key)
+		}
+	}
+	return o, nil
+}
+
+func (o *Ontology) add(def PredicateDef) error {
+	if strings.TrimSpace(def.Name) == "" {
+		return errors.New("predicate name is required")
+	}
+	d := &def
+	for _, name := range append([]string{def.Name}, def.Aliases...) {
+		key := predicateKey(name)
+		if existing, ok := o.byKey[key]; ok {
+			return fmt.Errorf("predicate %q clashes with %q", name, existing.Name)
+		}
+		o.byKey[key] = d
+	}
+	if def.Inverse != "" {
+		key := predicateKey(def.Inverse)
+		if existing, ok := o.inverses[key]; ok {
+			return fmt.Errorf("inverse %q of %q is already the inverse of %q", def.Inverse, def.Name, existing.Name)
+		}
+		o.inverses[key] = d
+	}
+	o.defs = append(o.defs, d)
+	return nil
+}
+
+// Predicates returns the declared predicates in declaration order.
+func (o *Ontology) Predicates() []PredicateDef {
+	defs := make([]PredicateDef, len(o.defs))
+	for i, d := range o.defs {
+		defs[i] = *d
+	}
+	return defs
+}
+
+// Lookup resolves a predicate or alias to its declaration.
+func (o *Ontology) Lookup(predicate string) (PredicateDef, bool) {
+	d, ok := o.byKey[predicateKey(predicate)]
+	if !ok {
+		return PredicateDef{}, false
+	}
+	return *d, true
+}
+
+// single reports whether predicate is declared with Single cardinality.
+func (o *Ontology) single(predicate string) bool {
+	d, ok := o.byKey[predicateKey(predicate)]
+	return ok && d.Cardinality == Single
+}
+
+// Normalize returns fact with its predicate rewritten to the canonical
+// name, or an *OntologyError if the fact is not allowed.
+func (o *Ontology) Normalize(fact RememberRequest) (RememberRequest, error) {
+	facts, err := o.normalize([]RememberRequest{fact}, -1)
+	if err != nil {
+		return fact, err
+	}
+	return facts[0], nil
+}
+
+// NormalizeMany normalises facts as one request, so cardinality is
+// checked across them and type facts among them are used for type
+// checks. The input slice is not modified.
+func (o *Ontology) NormalizeMany(facts []RememberRequest) ([]RememberRequest, error) {
+	return o.normalize(facts, 0)
+}
+
+// normalize rewrites and validates facts. base is the index reported
+// for the first fact, or -1 for a single Remember.
+func (o *Ontology) normalize(facts []RememberRequest, base int) ([]RememberRequest, error) {
+	index := func(i int) int {
+		if base < 0 {
+			return -1
+		}
+		return base + i
+	}
+
+	out := slices.Clone(facts)
+	defs := make([]*PredicateDef, len(out))
+	types := make(map[string]string)
+	for i := range out {
+		f := &out[i]
+		key := predicateKey(f.Predicate)
+		if d, ok := o.byKey[key]; ok {
+			f.Predicate, defs[i] = d.Name, d
+		} else if d, ok := o.inverses[key]; ok {
+			f.Subject, f.Object = f.Object, f.Subject
+			f.Predicate, defs[i] = d.Name, d
+		} else if slices.Contains(typePredicates, key) {
+			f.Predicate = key
+		} else if o.Strict {
+			return nil, &OntologyError{Fact: facts[i], Index: index(i), Reason: "predicate is not in the ontology"}
+		}
+		if slices.Contains(typePredicates, f.Predicate) {
+			types[f.Subject] = f.Object
+		}
+	}
+
+	objects := make(map[[2]string]string)
+	for i, f := range out {
+		d := defs[i]
+		if d == nil {
+			continue
+		}
+		// Ended values are history, not competing current values.
+		if d.Cardinality == Single && f.ValidTo == nil {
+			k := [2]string{f.Subject, d.Name}
+			if prev, ok := objects[k]; ok && prev != f.Object {
+				return nil, &OntologyError{Fact: facts[i], Index: index(i),
+					Reason: fmt.Sprintf("%s allows a single value, already given %q", d.Name, prev), kind: violationCardinality}
+			}
+			objects[k] = f.Object
+		}
+		if err := o.checkType(d.SubjectTypes, f.Subject, types); err != "" {
+			return nil, &OntologyError{Fact: facts[i], Index: index(i), Reason: "subject " + err, kind: violationType}
+		}
+		if err := o.checkType(d.ObjectTypes, f.Object, types); err != "" {
+			return nil, &OntologyError{Fact: facts[i], Index: index(i), Reason: "object " + err, kind: violationType}
+		}
+	}
+	return out, nil
+}
+
+// checkType returns a reason if entity has a known type not in allowed.
+func (o *Ontology) checkType(allowed []string, entity string, types map[string]string) string {
+	if len(allowed) == 0 {
+		return ""
+	}
+	typ := types[entity]
+	if typ == "" && o.TypeOf != nil {
+		typ = o.TypeOf(entity)
+	}
+	if typ == "" || slices.ContainsFunc(allowed, func(a string) bool { return strings.EqualFold(a, typ) }) {
+		return ""
+	}
+	return fmt.Sprintf("%q has type %q, expected %s", entity, typ, strings.Join(allowed, " or "))
+}
+
+// Describe returns the vocabulary as text for an LLM, one predicate per
+// line.
+func (o *Ontology) Describe() string {
+	var b strings.Builder
+	if o.Strict {
+		b.WriteString("Use only these predicates (and type, is_a or instance_of for entity types):")
+	} else {
+		b.WriteString("Prefer these predicates:")
+	}
+	for _, d := range o.defs {
+		b.WriteString("\n- " + d.Name)
+		var notes []string
+		if len(d.SubjectTypes) > 0 || len(d.ObjectTypes) > 0 {
+			notes = append(notes, orAny(d.SubjectTypes)+" -> "+orAny(d.ObjectTypes))
+		}
+		if d.Cardinality == Single {
+			notes = append(notes, "single value")
+		}
+		if len(notes) > 0 {
+			b.WriteString(" (" + strings.Join(notes, ", ") + ")")
+		}
+		if d.Description != "" {
+			b.WriteString(": " + d.Description)
+		}
+	}
+	return b.String()
+}
+
+// annotate appends the vocabulary to the description of the remember
+// and remember_many tools; other definitions are returned unchanged.
+func (o *Ontology) annotate(def tools.Definition) tools.Definition {
+	if def.Name == "remember" || def.Name == "remember_many" {
+		def.Description += "\n\n" + o.Describe()
+	}
+	return def
+}
+
+func orAny(types []string) string {
+	if len(types) == 0 {
+		return "any"
+	}
+	return strings.Join(types, "|")
+}
+
+// predicateKey folds a predicate to a comparison key: camelCase, spaces
+// and hyphens become snake_case and everything is lower-cased.
+func predicateKey(predicate string) string {
+	var b strings.Builder
+	prevLower := false
+	for _, r := range strings.TrimSpace(predicate) {
+		switch {
+		case r == ' ' || r == '-' || r == '_':
+			b.WriteByte('_')
+			prevLower = false
+		case unicode.IsUpper(r):
+			if prevLower {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+			prevLower = false
+		default:
+			b.WriteRune(r)
+			prevLower = unicode.IsLower(r) || unicode.IsDigit(r)
+		}
+	}
+	return b.String()
+}
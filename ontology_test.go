@@ -0,0 +1,204 @@
+package gomind
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ingate/gomind-go-sdk/tools"
+)
+
+func testOntology(t *testing.T) *Ontology {
+	t.Helper()
+	o, err := NewOntology(
+		PredicateDef{
+			Name:         "works_at",
+			Description:  "Current employer",
+			Aliases:      []string{"employed_by"},
+			Inverse:      "employs",
+			Cardinality:  Single,
+			SubjectTypes: []string{"Person"},
+			ObjectTypes:  []string{"Organization"},
+		},
+		PredicateDef{Name: "type"},
+		PredicateDef{Name: "knows", Inverse: "knows"},
+	)
+	if err != nil {
+		t.Fatalf("NewOntology: %v", err)
+	}
+	return o
+}
+
+func TestOntologyNormalize(t *testing.T) {
+	o := testOntology(t)
+	tests := []struct {
+		in   RememberRequest
+		want RememberRequest
+	}{
+		{RememberRequest{Subject: "John", Predicate: "worksAt", Object: "Acme"}, RememberRequest{Subject: "John", Predicate: "works_at", Object: "Acme"}},
+		{RememberRequest{Subject: "John", Predicate: "Employed-By", Object: "Acme"}, RememberRequest{Subject: "John", Predicate: "works_at", Object: "Acme"}},
+		{RememberRequest{Subject: "Acme", Predicate: "employs", Object: "John"}, RememberRequest{Subject: "John", Predicate: "works_at", Object: "Acme"}},
+		{RememberRequest{Subject: "John", Predicate: "knows", Object: "Mary"}, RememberRequest{Subject: "John", Predicate: "knows", Object: "Mary"}},
+		{RememberRequest{Subject: "John", Predicate: "likesTea", Object: "yes"}, RememberRequest{Subject: "John", Predicate: "likesTea", Object: "yes"}},
+	}
+	for _, tt := range tests {
+		got, err := o.Normalize(tt.in)
+		if err != nil {
+			t.Fatalf("Normalize(%+v): %v", tt.in, err)
+		}
+		if got != tt.want {
+			t.Errorf("Normalize(%+v) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+
+	o.Strict = true
+	_, err := o.Normalize(RememberRequest{Subject: "John", Predicate: "likesTea", Object: "yes"})
+	var ontErr *OntologyError
+	if !errors.As(err, &ontErr) || !errors.Is(err, ErrOntologyViolation) || ontErr.Index != -1 {
+		t.Errorf("expected strict rejection, got %v", err)
+	}
+	// Type facts need no declaration.
+	got, err := o.Normalize(RememberRequest{Subject: "John", Predicate: "isA", Object: "Person"})
+	if err != nil || got.Predicate != "is_a" {
+		t.Errorf("expected type fact to pass strict mode, got %+v, %v", got, err)
+	}
+}
+
+func TestOntologyNormalizeMany(t *testing.T) {
+	o := testOntology(t)
+	o.TypeOf = func(entity string) string {
+		if entity == "Rex" {
+			return "Dog"
+		}
+		return ""
+	}
+
+	in := []RememberRequest{
+		{Subject: "John", Predicate: "type", Object: "Person"},
+		{Subject: "Acme", Predicate: "employs", Object: "John"},
+		{Subject: "John", Predicate: "works_at", Object: "Acme"},
+	}
+	got, err := o.NormalizeMany(in)
+	if err != nil {
+		t.Fatalf("NormalizeMany: %v", err)
+	}
+	if got[1].Subject != "John" || in[1].Subject != "Acme" {
+		t.Errorf("expected a flipped copy, got %+v (input %+v)", got[1], in[1])
+	}
+
+	// An ended value next to the current one is a history, not a
+	// cardinality violation.
+	o.Strict = true
+	if _, err := o.NormalizeMany([]RememberRequest{
+		{Subject: "John", Predicate: "works_at", Object: "Initech", ValidFrom: date(2019, 1), ValidTo: date(2022, 3)},
+		{Subject: "John", Predicate: "works_at", Object: "Acme", ValidFrom: date(2022, 3)},
+	}); err != nil {
+		t.Errorf("expected a history batch to pass, got %v", err)
+	}
+	o.Strict = false
+
+	tests := []struct {
+		name  string
+		facts []RememberRequest
+		index int
+	}{
+		{"cardinality", []RememberRequest{
+			{Subject: "John", Predicate: "works_at", Object: "Acme"},
+			{Subject: "John", Predicate: "employedBy", Object: "Globex"},
+		}, 1},
+		{"subject type from batch", []RememberRequest{
+			{Subject: "Acme", Predicate: "works_at", Object: "Globex"},
+			{Subject: "Acme", Predicate: "type", Object: "Organization"},
+		}, 0},
+		{"subject type from resolver", []RememberRequest{
+			{Subject: "Rex", Predicate: "works_at", Object: "Acme"},
+		}, 0},
+	}
+	for _, tt := range tests {
+		_, err := o.NormalizeMany(tt.facts)
+		var ontErr *OntologyError
+		if !errors.As(err, &ontErr) || ontErr.Index != tt.index {
+			t.Errorf("%s: expected rejection of fact %d, got %v", tt.name, tt.index, err)
+		}
+	}
+}
+
+func TestNewOntologyRejectsClashes(t *testing.T) {
+	if _, err := NewOntology(PredicateDef{Name: "works_at"}, PredicateDef{Name: "employer", Aliases: []string{"worksAt"}}); err == nil {
+		t.Error("expected alias clash error")
+	}
+	if _, err := NewOntology(PredicateDef{Name: ""}); err == nil {
+		t.Error("expected missing name error")
+	}
+}
+
+func TestClientOntology(t *testing.T) {
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"ok","result":{}}`))
+	}))
+	defer srv.Close()
+
+	o := testOntology(t)
+	o.Strict = true
+	client, err := NewClient("test-key", WithBaseURL(srv.URL), WithOntology(o))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	ctx := context.Background()
+
+	if _, err := client.Remember(ctx, "Acme", "employs", "John", ""); err != nil {
+		t.Fatalf("Remember: %v", err)
+	}
+	var sent RememberRequest
+	if err := json.Unmarshal([]byte(bodies[0]), &sent); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if sent.Subject != "John" || sent.Predicate != "works_at" || sent.Object != "Acme" {
+		t.Errorf("unexpected request %+v", sent)
+	}
+
+	_, err = client.HandleToolCall(ctx, "remember_many", `{"facts":[{"subject":"John","predicate":"likes","object":"tea"}]}`)
+	if !errors.Is(err, ErrOntologyViolation) {
+		t.Errorf("expected ontology violation, got %v", err)
+	}
+	if len(bodies) != 1 {
+		t.Errorf("rejected facts must not reach the API, got %d requests", len(bodies))
+	}
+	text := client.HandleToolCallText(ctx, "remember", `{"subject":"John","predicate":"likes","object":"tea"}`)
+	if !strings.Contains(text, "Use a predicate from the tool description") {
+		t.Errorf("unexpected tool text %q", text)
+	}
+	text = client.HandleToolCallText(ctx, "remember_many", `{"facts":[`+
+		`{"subject":"John","predicate":"works_at","object":"Acme"},`+
+		`{"subject":"John","predicate":"works_at","object":"Globex"}]}`)
+	if !strings.Contains(text, "Remember only one value") {
+		t.Errorf("unexpected cardinality tool text %q", text)
+	}
+
+	reg := NewToolRegistry(client)
+	reg.SetPrefix("memory_")
+	for path, defs := range map[string][]tools.Definition{
+		"registry": reg.Definitions(),
+		"client":   client.ToolDefinitions(),
+	} {
+		for _, def := range defs {
+			hasVocab := strings.Contains(def.Description, "- works_at (Person -> Organization, single value): Current employer")
+			name := strings.TrimPrefix(def.Name, "memory_")
+			if want := name == "remember" || name == "remember_many"; hasVocab != want {
+				t.Errorf("%s tool %s: vocabulary in description = %v, want %v", path, def.Name, hasVocab, want)
+			}
+		}
+	}
+	if strings.Contains(tools.Definitions()[0].Description, "works_at") {
+		t.Error("the shared definitions must not be modified")
+	}
+}
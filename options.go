@@ -54,6 +54,31 @@ func WithTimeout(timeout time.Duration) Option {
 	}
 }
 
+// WithToolPolicy restricts the tools and collections HandleToolCall will
+// act on. Use ReadOnlyPolicy or AppendOnlyPolicy for untrusted agents.
+func WithToolPolicy(policy ToolPolicy) Option {
+	return func(c *Client) {
+		c.toolPolicy = policy
+	}
+}
+
+// WithUsageAccountant records the tokens and latency of every mind
+// request in a and enforces its budgets.
+func WithUsageAccountant(a *UsageAccountant) Option {
+	return func(c *Client) {
+		c.usage = a
+	}
+}
+
+// WithOntology normalises and validates every fact stored through
+// Remember, RememberMany and the remember tools against o, and lists its
+// vocabulary in the remember tool descriptions.
+func WithOntology(o *Ontology) Option {
+	return func(c *Client) {
+		c.ontology = o
+	}
+}
+
 // WithCollection sets a default collection code applied to every memory
 // operation when the per-request Collection field is nil. Reserved
 // aliases ("default", "none", "null", "nil", "undefined") and the empty
@@ -0,0 +1,127 @@
+package rdf
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	gomind "github.com/ingate/gomind-go-sdk"
+)
+
+// ImportOptions configures Import.
+type ImportOptions struct {
+	// Format of the input. Defaults to NTriples.
+	Format Format
+
+	// Mapping converts IRIs to Gomind names.
+	Mapping Mapping
+
+	// BatchSize is the number of facts per remember_many request.
+	// Defaults to gomind.DefaultImportBatchSize.
+	BatchSize int
+
+	// Collection and Source are passed through to every
+	// RememberManyRequest. See RememberRequest.Collection for the
+	// *string semantics.
+	Collection *string
+	Source     string
+
+	// Progress, when set, is called after every batch.
+	Progress func(ImportSummary)
+}
+
+// ImportSummary reports the outcome of Import.
+type ImportSummary struct {
+	// Triples counts triples read.
+	Triples int
+	// Imported counts facts written.
+	Imported int
+	// Batches counts remember_many requests sent.
+	Batches int
+}
+
+// Import reads RDF from r and stores every triple as a fact through
+// RememberManyWithOptions. N-Triples and Turtle are streamed, so memory
+// use is bounded by the batch size. Batches already written stay
+// written if the import fails part way.
+func Import(ctx context.Context, c *gomind.Client, r io.Reader, opts ImportOptions) (*ImportSummary, error) {
+	opts.Format = cmp.Or(opts.Format, NTriples)
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = gomind.DefaultImportBatchSize
+	}
+
+	tr, err := NewReader(r, opts.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &ImportSummary{}
+	var batch []gomind.RememberRequest
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		err := c.RememberManyWithOptions(ctx, gomind.RememberManyRequest{
+			Facts:      batch,
+			Source:     opts.Source,
+			Collection: opts.Collection,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to import batch after %d triples: %w", summary.Triples, err)
+		}
+		summary.Imported += len(batch)
+		summary.Batches++
+		batch = nil
+		if opts.Progress != nil {
+			opts.Progress(*summary)
+		}
+		return nil
+	}
+
+	for {
+		t, err := tr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return summary, err
+		}
+		summary.Triples++
+		req, err := opts.Mapping.Remember(t)
+		if err != nil {
+			return summary, fmt.Errorf("triple %d: %w", summary.Triples, err)
+		}
+		batch = append(batch, req)
+		if len(batch) >= opts.BatchSize {
+			if err := flush(); err != nil {
+				return summary, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return summary, err
+	}
+	return summary, nil
+}
+
+// Export writes every fact of the collection with the given code to w,
+// streaming page by page, and returns the number of facts written.
+func Export(ctx context.Context, c *gomind.Client, w io.Writer, orgID, code string, format Format, m Mapping) (int, error) {
+	tw, err := NewWriter(w, format, m)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for fact, err := range c.AllFacts(ctx, orgID, code, gomind.ListFactsOptions{}) {
+		if err != nil {
+			return n, err
+		}
+		if err := tw.Write(m.Triple(fact)); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, tw.Close()
+}
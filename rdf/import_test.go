@@ -0,0 +1,63 @@
+package rdf
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	gomind "github.com/ingate/gomind-go-sdk"
+)
+
+func TestImport(t *testing.T) {
+	var batches []gomind.RememberManyRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/remember_many" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		var req gomind.RememberManyRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		batches = append(batches, req)
+		json.NewEncoder(w).Encode(map[string]any{"status": "ok", "result": map[string]any{}})
+	}))
+	defer srv.Close()
+
+	client, err := gomind.NewClient("test-key", gomind.WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	input := `@prefix e: <urn:gomind:entity:> .
+@prefix p: <urn:gomind:predicate:> .
+e:John p:works_at e:Acme ; p:age 42 .
+e:Jane p:works_at e:Acme .
+`
+	var progress []int
+	summary, err := Import(context.Background(), client, strings.NewReader(input), ImportOptions{
+		Format:     Turtle,
+		BatchSize:  2,
+		Collection: gomind.CollectionScope("crm"),
+		Source:     "rdf",
+		Progress:   func(s ImportSummary) { progress = append(progress, s.Imported) },
+	})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if *summary != (ImportSummary{Triples: 3, Imported: 3, Batches: 2}) || !reflect.DeepEqual(progress, []int{2, 3}) {
+		t.Errorf("unexpected summary %+v, progress %v", summary, progress)
+	}
+	if len(batches) != 2 || *batches[0].Collection != "crm" || batches[0].Source != "rdf" {
+		t.Fatalf("unexpected batches %+v", batches)
+	}
+	if got := batches[0].Facts[1]; got != (gomind.RememberRequest{Subject: "John", Predicate: "age", Object: "42"}) {
+		t.Errorf("unexpected fact %+v", got)
+	}
+
+	_, err = Import(context.Background(), client, strings.NewReader("<a> <b> ."), ImportOptions{})
+	if err == nil {
+		t.Error("expected a syntax error")
+	}
+}
@@ -0,0 +1,433 @@
+package rdf
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// jsonldReader reads a JSON-LD document: a node object, an array of
+// node objects, or an object with "@graph". It supports inline
+// "@context" objects with prefixes, terms (optionally with
+// "@type": "@id"), "@vocab" and "@base"; nested node objects; and
+// "@value" objects with "@type" or "@language". Remote contexts and
+// "@list" are not supported.
+type jsonldReader struct {
+	r      io.Reader
+	queue  []Triple
+	done   bool
+	blanks int
+}
+
+func (jr *jsonldReader) Read() (Triple, error) {
+	if !jr.done {
+		jr.done = true
+		if err := jr.load(); err != nil {
+			return Triple{}, err
+		}
+	}
+	if len(jr.queue) == 0 {
+		return Triple{}, io.EOF
+	}
+	t := jr.queue[0]
+	jr.queue = jr.queue[1:]
+	return t, nil
+}
+
+// jsonldContext is an active JSON-LD context.
+type jsonldContext struct {
+	terms map[string]string
+	// ids lists terms whose string values are IRIs.
+	ids   map[string]bool
+	vocab string
+	base  *url.URL
+}
+
+func (jr *jsonldReader) load() error {
+	dec := json.NewDecoder(jr.r)
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return fmt.Errorf("rdf: invalid JSON-LD: %w", err)
+	}
+
+	ctx := &jsonldContext{terms: map[string]string{}, ids: map[string]bool{}}
+	var nodes []any
+	switch v := doc.(type) {
+	case []any:
+		nodes = v
+	case map[string]any:
+		graph, ok := v["@graph"]
+		if !ok {
+			nodes = []any{v}
+			break
+		}
+		if err := ctx.apply(v["@context"]); err != nil {
+			return err
+		}
+		if nodes, ok = graph.([]any); !ok {
+			nodes = []any{graph}
+		}
+	default:
+		return fmt.Errorf("rdf: JSON-LD document must be an object or array")
+	}
+	for _, n := range nodes {
+		obj, ok := n.(map[string]any)
+		if !ok {
+			return fmt.Errorf("rdf: JSON-LD node must be an object, got %T", n)
+		}
+		if _, err := jr.node(ctx, obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// apply merges a "@context" value into c.
+func (c *jsonldContext) apply(v any) error {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case []any:
+		for _, item := range v {
+			if err := c.apply(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	case string:
+		return fmt.Errorf("rdf: remote JSON-LD context %q is not supported", v)
+	case map[string]any:
+		for key, def := range v {
+			switch key {
+			case "@vocab":
+				c.vocab, _ = def.(string)
+				continue
+			case "@base":
+				s, _ := def.(string)
+				base, err := url.Parse(s)
+				if err != nil {
+					return fmt.Errorf("rdf: invalid JSON-LD @base %q", s)
+				}
+				c.base = base
+				continue
+			}
+			switch def := def.(type) {
+			case string:
+				c.terms[key] = def
+			case map[string]any:
+				id, _ := def["@id"].(string)
+				c.terms[key] = id
+				if def["@type"] == "@id" {
+					c.ids[key] = true
+				}
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("rdf: invalid JSON-LD @context of type %T", v)
+}
+
+func (c *jsonldContext) clone() *jsonldContext {
+	cc := *c
+	cc.terms = maps.Clone(c.terms)
+	cc.ids = maps.Clone(c.ids)
+	return &cc
+}
+
+// expandTerm expands a property or type name, returning "" for names
+// the context does not define. Terms may map to other terms; a cycle is
+// an error.
+func (c *jsonldContext) expandTerm(name string) (string, error) {
+	seen := map[string]bool{}
+	for {
+		iri, ok := c.terms[name]
+		if !ok || iri == name {
+			break
+		}
+		if seen[name] {
+			return "", fmt.Errorf("rdf: JSON-LD @context term %q is defined cyclically", name)
+		}
+		seen[name] = true
+		name = iri
+	}
+	if prefix, local, ok := strings.Cut(name, ":"); ok {
+		if ns, ok := c.terms[prefix]; ok {
+			return ns + local, nil
+		}
+		return name, nil
+	}
+	if c.vocab != "" {
+		return c.vocab + name, nil
+	}
+	return "", nil
+}
+
+// expandID expands a node identifier, resolving relative IRIs against
+// @base.
+func (c *jsonldContext) expandID(id string) Term {
+	if label, ok := strings.CutPrefix(id, "_:"); ok {
+		return NewBlank(label)
+	}
+	if prefix, local, ok := strings.Cut(id, ":"); ok {
+		if ns, ok := c.terms[prefix]; ok {
+			return NewIRI(ns + local)
+		}
+		return NewIRI(id)
+	}
+	if c.base != nil {
+		if ref, err := url.Parse(id); err == nil {
+			return NewIRI(c.base.ResolveReference(ref).String())
+		}
+	}
+	return NewIRI(id)
+}
+
+// node emits the triples of a node object and returns its subject.
+func (jr *jsonldReader) node(ctx *jsonldContext, obj map[string]any) (Term, error) {
+	if local, ok := obj["@context"]; ok {
+		ctx = ctx.clone()
+		if err := ctx.apply(local); err != nil {
+			return Term{}, err
+		}
+	}
+
+	var subject Term
+	if id, ok := obj["@id"].(string); ok {
+		subject = ctx.expandID(id)
+	} else {
+		jr.blanks++
+		subject = NewBlank("b" + strconv.Itoa(jr.blanks))
+	}
+
+	for _, key := range sortedKeys(obj) {
+		value := obj[key]
+		switch key {
+		case "@id", "@context":
+			continue
+		case "@type":
+			for _, v := range asList(value) {
+				s, ok := v.(string)
+				if !ok {
+					return Term{}, fmt.Errorf("rdf: JSON-LD @type must be a string, got %T", v)
+				}
+				typ, err := ctx.expandTerm(s)
+				if err != nil {
+					return Term{}, err
+				}
+				if typ == "" {
+					typ = ctx.expandID(s).Value
+				}
+				jr.queue = append(jr.queue, Triple{Subject: subject, Predicate: NewIRI(RDFType), Object: NewIRI(typ)})
+			}
+			continue
+		}
+		if strings.HasPrefix(key, "@") {
+			continue
+		}
+		predicate, err := ctx.expandTerm(key)
+		if err != nil {
+			return Term{}, err
+		}
+		if predicate == "" {
+			// Terms the context does not define are dropped, as in
+			// JSON-LD expansion.
+			continue
+		}
+		for _, v := range asList(value) {
+			object, err := jr.value(ctx, v, ctx.ids[key])
+			if err != nil {
+				return Term{}, fmt.Errorf("rdf: JSON-LD property %q: %w", key, err)
+			}
+			jr.queue = append(jr.queue, Triple{Subject: subject, Predicate: NewIRI(predicate), Object: object})
+		}
+	}
+	return subject, nil
+}
+
+func (jr *jsonldReader) value(ctx *jsonldContext, v any, isID bool) (Term, error) {
+	switch v := v.(type) {
+	case string:
+		if isID {
+			return ctx.expandID(v), nil
+		}
+		return NewLiteral(v), nil
+	case json.Number:
+		datatype := XSDNamespace + "integer"
+		if strings.ContainsAny(v.String(), ".eE") {
+			datatype = XSDNamespace + "double"
+		}
+		return Term{Kind: Literal, Value: v.String(), Datatype: datatype}, nil
+	case bool:
+		return Term{Kind: Literal, Value: strconv.FormatBool(v), Datatype: XSDNamespace + "boolean"}, nil
+	case map[string]any:
+		if raw, ok := v["@value"]; ok {
+			lit, err := jr.value(ctx, raw, false)
+			if err != nil {
+				return Term{}, err
+			}
+			if lang, ok := v["@language"].(string); ok {
+				lit.Language, lit.Datatype = lang, ""
+			}
+			if typ, ok := v["@type"].(string); ok {
+				datatype, err := ctx.expandTerm(typ)
+				if err != nil {
+					return Term{}, err
+				}
+				if lit.Datatype = datatype; lit.Datatype == "" {
+					lit.Datatype = ctx.expandID(typ).Value
+				}
+			}
+			return lit, nil
+		}
+		if _, ok := v["@list"]; ok {
+			return Term{}, fmt.Errorf("@list is not supported")
+		}
+		return jr.node(ctx, v)
+	}
+	return Term{}, fmt.Errorf("unsupported value of type %T", v)
+}
+
+// asList flattens a JSON-LD value that may be an array or a "@set".
+func asList(v any) []any {
+	if obj, ok := v.(map[string]any); ok {
+		if set, ok := obj["@set"]; ok {
+			return asList(set)
+		}
+	}
+	if list, ok := v.([]any); ok {
+		return list
+	}
+	return []any{v}
+}
+
+// jsonldWriter writes a JSON-LD document with an "@context" holding the
+// mapping's namespaces and an "@graph" with one node object per run of
+// triples sharing a subject. Properties use compact IRIs where a
+// namespace matches and full IRIs otherwise.
+type jsonldWriter struct {
+	w       *bufio.Writer
+	m       Mapping
+	started bool
+	nodes   int
+
+	subject *Term
+	props   []string
+	values  map[string][]any
+}
+
+func newJSONLDWriter(w io.Writer, m Mapping) *jsonldWriter {
+	return &jsonldWriter{w: bufio.NewWriter(w), m: m}
+}
+
+func (jw *jsonldWriter) header() error {
+	jw.started = true
+	context := make(map[string]string, len(jw.m.Namespaces))
+	maps.Copy(context, jw.m.Namespaces)
+	data, err := json.Marshal(context)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(jw.w, "{\n  \"@context\": %s,\n  \"@graph\": [", data)
+	return nil
+}
+
+func (jw *jsonldWriter) Write(t Triple) error {
+	if !jw.started {
+		if err := jw.header(); err != nil {
+			return err
+		}
+	}
+	if jw.subject == nil || *jw.subject != t.Subject {
+		if err := jw.flush(); err != nil {
+			return err
+		}
+		jw.subject = &t.Subject
+		jw.values = make(map[string][]any)
+	}
+
+	key := jw.compact(t.Predicate.Value)
+	var value any
+	switch {
+	case t.Predicate.Value == RDFType && t.Object.Kind == IRI:
+		key, value = "@type", jw.compact(t.Object.Value)
+	case t.Object.Kind == Literal:
+		v := map[string]string{"@value": t.Object.Value}
+		if t.Object.Language != "" {
+			v["@language"] = t.Object.Language
+		} else if t.Object.Datatype != "" && t.Object.Datatype != XSDString {
+			v["@type"] = jw.compact(t.Object.Datatype)
+		}
+		value = v
+	default:
+		value = map[string]string{"@id": jw.id(t.Object)}
+	}
+	if _, ok := jw.values[key]; !ok {
+		jw.props = append(jw.props, key)
+	}
+	jw.values[key] = append(jw.values[key], value)
+	return nil
+}
+
+// flush writes the pending node object.
+func (jw *jsonldWriter) flush() error {
+	if jw.subject == nil {
+		return nil
+	}
+	if jw.nodes > 0 {
+		jw.w.WriteString(",")
+	}
+	jw.nodes++
+
+	id, _ := json.Marshal(jw.id(*jw.subject))
+	fmt.Fprintf(jw.w, "\n    {\"@id\": %s", id)
+	for _, key := range jw.props {
+		k, _ := json.Marshal(key)
+		v, err := json.Marshal(jw.values[key])
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(jw.w, ", %s: %s", k, v)
+	}
+	jw.w.WriteString("}")
+
+	jw.subject, jw.props, jw.values = nil, nil, nil
+	return nil
+}
+
+func (jw *jsonldWriter) Close() error {
+	if !jw.started {
+		if err := jw.header(); err != nil {
+			return err
+		}
+	}
+	if err := jw.flush(); err != nil {
+		return err
+	}
+	if jw.nodes > 0 {
+		jw.w.WriteString("\n  ")
+	}
+	jw.w.WriteString("]\n}\n")
+	return jw.w.Flush()
+}
+
+func (jw *jsonldWriter) id(t Term) string {
+	if t.Kind == Blank {
+		return "_:" + t.Value
+	}
+	return jw.compact(t.Value)
+}
+
+func (jw *jsonldWriter) compact(iri string) string {
+	for _, prefix := range sortedKeys(jw.m.Namespaces) {
+		if local, ok := strings.CutPrefix(iri, jw.m.Namespaces[prefix]); ok && local != "" {
+			return prefix + ":" + local
+		}
+	}
+	return iri
+}
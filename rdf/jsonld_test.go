@@ -0,0 +1,68 @@
+package rdf
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	gomind "github.com/ingate/gomind-go-sdk"
+)
+
+func TestJSONLDReader(t *testing.T) {
+	input := `{
+  "@context": {
+    "@vocab": "http://schema.org/",
+    "ex": "http://example.org/",
+    "worksFor": {"@id": "http://schema.org/worksFor", "@type": "@id"}
+  },
+  "@graph": [
+    {
+      "@id": "ex:john",
+      "@type": "Person",
+      "name": "John",
+      "age": 42,
+      "worksFor": "ex:acme",
+      "knows": {"name": "Jane"},
+      "description": {"@value": "Hallo", "@language": "de"}
+    }
+  ]
+}`
+	got := readAll(t, input, JSONLD)
+	want := []string{
+		`<http://example.org/john> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://schema.org/Person> .`,
+		`<http://example.org/john> <http://schema.org/age> "42"^^<http://www.w3.org/2001/XMLSchema#integer> .`,
+		`<http://example.org/john> <http://schema.org/description> "Hallo"@de .`,
+		`_:b1 <http://schema.org/name> "Jane" .`,
+		`<http://example.org/john> <http://schema.org/knows> _:b1 .`,
+		`<http://example.org/john> <http://schema.org/name> "John" .`,
+		`<http://example.org/john> <http://schema.org/worksFor> <http://example.org/acme> .`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected triples:\n%s", strings.Join(got, "\n"))
+	}
+
+	m := Mapping{Base: "http://example.org/", PredicateBase: "http://schema.org/"}
+	facts, err := ReadFacts(strings.NewReader(input), JSONLD, m)
+	if err != nil {
+		t.Fatalf("ReadFacts: %v", err)
+	}
+	if facts[0] != (gomind.Fact{Subject: "john", Predicate: "type", Object: "http://schema.org/Person"}) ||
+		facts[6] != (gomind.Fact{Subject: "john", Predicate: "worksFor", Object: "acme"}) {
+		t.Errorf("unexpected facts %+v", facts)
+	}
+}
+
+func TestJSONLDReaderUnsupported(t *testing.T) {
+	for _, input := range []string{
+		`{"@context": "https://schema.org", "name": "John"}`,
+		`{"@context": {"ex": "http://ex/"}, "ex:list": {"@list": ["a"]}}`,
+		`"just a string"`,
+		// Cyclic term definitions used to overflow the stack.
+		`{"@context":{"a":"b","b":"a"},"@id":"x","a":"y"}`,
+		`{"@context":{"a":"b","b":"a"},"@id":"x","@type":"a"}`,
+	} {
+		if _, err := ReadFacts(strings.NewReader(input), JSONLD, Mapping{}); err == nil {
+			t.Errorf("%s: expected an error", input)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package rdf
+
+import (
+	"cmp"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	gomind "github.com/ingate/gomind-go-sdk"
+)
+
+// Default IRI bases used when Mapping leaves them empty.
+const (
+	DefaultBase          = "urn:gomind:entity:"
+	DefaultPredicateBase = "urn:gomind:predicate:"
+)
+
+// Mapping converts between Gomind names and IRIs. The zero value uses
+// DefaultBase and DefaultPredicateBase.
+//
+// Names are mapped to IRIs as follows, first match wins:
+//
+//   - predicates listed in Predicates use the listed IRI; "type" maps
+//     to rdf:type unless overridden;
+//   - names starting with "_:" become blank nodes (entities only);
+//   - names of the form prefix:local with a prefix in Namespaces are
+//     expanded;
+//   - names containing "://" are used as IRIs unchanged;
+//   - anything else is path-escaped and appended to the base.
+//
+// Importing applies the inverse, so facts survive a round trip. IRIs
+// matching no rule are kept whole as the name.
+type Mapping struct {
+	// Base is the IRI prefix for entities.
+	Base string
+	// PredicateBase is the IRI prefix for predicates.
+	PredicateBase string
+	// Namespaces maps prefixes to namespace IRIs, for example
+	// "foaf": "http://xmlns.com/foaf/0.1/".
+	Namespaces map[string]string
+	// Predicates maps Gomind predicates to IRIs.
+	Predicates map[string]string
+}
+
+func (m Mapping) base() string {
+	return cmp.Or(m.Base, DefaultBase)
+}
+
+func (m Mapping) predicateBase() string {
+	return cmp.Or(m.PredicateBase, DefaultPredicateBase)
+}
+
+func (m Mapping) predicateIRI(predicate string) string {
+	if iri, ok := m.Predicates[predicate]; ok {
+		return iri
+	}
+	if predicate == "type" {
+		return RDFType
+	}
+	return m.expand(predicate, m.predicateBase())
+}
+
+func (m Mapping) expand(name, base string) string {
+	if prefix, local, ok := strings.Cut(name, ":"); ok {
+		if ns, ok := m.Namespaces[prefix]; ok {
+			return ns + local
+		}
+	}
+	if strings.Contains(name, "://") {
+		return name
+	}
+	return base + url.PathEscape(name)
+}
+
+func (m Mapping) entityTerm(name string) Term {
+	if label, ok := strings.CutPrefix(name, "_:"); ok {
+		return NewBlank(label)
+	}
+	return NewIRI(m.expand(name, m.base()))
+}
+
+// compact is the inverse of expand.
+func (m Mapping) compact(iri, base string) string {
+	if rest, ok := strings.CutPrefix(iri, base); ok {
+		if name, err := url.PathUnescape(rest); err == nil {
+			return name
+		}
+	}
+	// Prefer the longest matching namespace.
+	best, found := "", false
+	for _, prefix := range sortedKeys(m.Namespaces) {
+		ns := m.Namespaces[prefix]
+		if strings.HasPrefix(iri, ns) && (!found || len(ns) > len(m.Namespaces[best])) {
+			best, found = prefix, true
+		}
+	}
+	if found {
+		return best + ":" + strings.TrimPrefix(iri, m.Namespaces[best])
+	}
+	return iri
+}
+
+func (m Mapping) predicateName(iri string) string {
+	for _, predicate := range sortedKeys(m.Predicates) {
+		if m.Predicates[predicate] == iri {
+			return predicate
+		}
+	}
+	if iri == RDFType {
+		return "type"
+	}
+	return m.compact(iri, m.predicateBase())
+}
+
+func (m Mapping) entityName(t Term) (string, error) {
+	switch t.Kind {
+	case IRI:
+		return m.compact(t.Value, m.base()), nil
+	case Blank:
+		return "_:" + t.Value, nil
+	}
+	return "", fmt.Errorf("rdf: literal %q cannot be an entity", t.Value)
+}
+
+// Triple converts a fact to a triple. The object is a resource when
+// Object is set and a literal holding Value otherwise.
+func (m Mapping) Triple(f gomind.Fact) Triple {
+	t := Triple{
+		Subject:   m.entityTerm(f.Subject),
+		Predicate: NewIRI(m.predicateIRI(f.Predicate)),
+	}
+	if f.Object != "" {
+		t.Object = m.entityTerm(f.Object)
+	} else {
+		t.Object = NewLiteral(f.Value)
+	}
+	return t
+}
+
+// RememberTriple converts a remember request to a triple. A
+// RememberRequest does not distinguish literals, so its Object is always
+// treated as a resource.
+func (m Mapping) RememberTriple(r gomind.RememberRequest) Triple {
+	return m.Triple(gomind.Fact{Subject: r.Subject, Predicate: r.Predicate, Object: r.Object})
+}
+
+// Fact converts a triple to a fact. Resource objects set Object and
+// literal objects set Value to the literal's lexical form.
+func (m Mapping) Fact(t Triple) (gomind.Fact, error) {
+	subject, err := m.entityName(t.Subject)
+	if err != nil {
+		return gomind.Fact{}, err
+	}
+	if t.Predicate.Kind != IRI {
+		return gomind.Fact{}, fmt.Errorf("rdf: predicate %q is not an IRI", t.Predicate.Value)
+	}
+	f := gomind.Fact{Subject: subject, Predicate: m.predicateName(t.Predicate.Value)}
+	if t.Object.Kind == Literal {
+		f.Value = t.Object.Value
+	} else if f.Object, err = m.entityName(t.Object); err != nil {
+		return gomind.Fact{}, err
+	}
+	return f, nil
+}
+
+// Remember converts a triple to a remember request, with the literal
+// value or the resource name as Object.
+func (m Mapping) Remember(t Triple) (gomind.RememberRequest, error) {
+	f, err := m.Fact(t)
+	if err != nil {
+		return gomind.RememberRequest{}, err
+	}
+	return gomind.RememberRequest{Subject: f.Subject, Predicate: f.Predicate, Object: cmp.Or(f.Object, f.Value)}, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
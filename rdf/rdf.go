@@ -0,0 +1,228 @@
+// Package rdf converts Gomind facts to and from RDF, reading and writing
+// N-Triples, Turtle and JSON-LD.
+//
+// A Mapping turns entity names and predicates into IRIs and back. Facts
+// with an Object become triples whose object is a resource, and facts
+// with a literal Value become triples with a literal object. Context,
+// Source and ID have no RDF counterpart and are not exported.
+//
+// Import streams a document into a collection through
+// RememberManyWithOptions, and Export writes a collection out.
+package rdf
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	gomind "github.com/ingate/gomind-go-sdk"
+)
+
+// Well-known IRIs.
+const (
+	RDFNamespace = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	XSDNamespace = "http://www.w3.org/2001/XMLSchema#"
+	RDFType      = RDFNamespace + "type"
+	XSDString    = XSDNamespace + "string"
+)
+
+// Format is an RDF serialisation.
+type Format string
+
+const (
+	NTriples Format = "ntriples"
+	Turtle   Format = "turtle"
+	JSONLD   Format = "jsonld"
+)
+
+// TermKind distinguishes the kinds of RDF term.
+type TermKind int
+
+const (
+	IRI TermKind = iota
+	Literal
+	Blank
+)
+
+// Term is an RDF term. Value holds the IRI, the lexical form of a
+// literal or the label of a blank node.
+type Term struct {
+	Kind     TermKind
+	Value    string
+	Datatype string
+	Language string
+}
+
+// NewIRI returns an IRI term.
+func NewIRI(iri string) Term { return Term{Kind: IRI, Value: iri} }
+
+// NewLiteral returns a plain string literal.
+func NewLiteral(value string) Term { return Term{Kind: Literal, Value: value} }
+
+// NewBlank returns a blank node with the given label.
+func NewBlank(label string) Term { return Term{Kind: Blank, Value: label} }
+
+// String returns the term in N-Triples syntax.
+func (t Term) String() string {
+	switch t.Kind {
+	case IRI:
+		return "<" + escapeIRI(t.Value) + ">"
+	case Blank:
+		return "_:" + t.Value
+	}
+	s := `"` + escapeString(t.Value) + `"`
+	switch {
+	case t.Language != "":
+		s += "@" + t.Language
+	case t.Datatype != "" && t.Datatype != XSDString:
+		s += "^^<" + escapeIRI(t.Datatype) + ">"
+	}
+	return s
+}
+
+// Triple is an RDF statement.
+type Triple struct {
+	Subject   Term
+	Predicate Term
+	Object    Term
+}
+
+// String returns the triple as an N-Triples line without the newline.
+func (t Triple) String() string {
+	return t.Subject.String() + " " + t.Predicate.String() + " " + t.Object.String() + " ."
+}
+
+// Reader reads triples. Read returns io.EOF after the last triple.
+type Reader interface {
+	Read() (Triple, error)
+}
+
+// Writer writes triples. Close finishes the document; it does not close
+// the underlying io.Writer.
+type Writer interface {
+	Write(Triple) error
+	Close() error
+}
+
+// SyntaxError reports malformed input.
+type SyntaxError struct {
+	Line int
+	Msg  string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("rdf: line %d: %s", e.Line, e.Msg)
+}
+
+// NewReader returns a Reader for the given format. N-Triples and Turtle
+// are parsed statement by statement; JSON-LD documents are decoded in
+// full on the first Read.
+func NewReader(r io.Reader, format Format) (Reader, error) {
+	switch format {
+	case NTriples, Turtle:
+		return newTurtleReader(r), nil
+	case JSONLD:
+		return &jsonldReader{r: r}, nil
+	}
+	return nil, fmt.Errorf("rdf: unknown format %q", format)
+}
+
+// NewWriter returns a Writer for the given format. Turtle and JSON-LD
+// output declares the namespaces of m and uses them to shorten IRIs.
+func NewWriter(w io.Writer, format Format, m Mapping) (Writer, error) {
+	switch format {
+	case NTriples:
+		return &ntriplesWriter{w: w}, nil
+	case Turtle:
+		return newTurtleWriter(w, m), nil
+	case JSONLD:
+		return newJSONLDWriter(w, m), nil
+	}
+	return nil, fmt.Errorf("rdf: unknown format %q", format)
+}
+
+// WriteFacts writes facts to w in the given format.
+func WriteFacts(w io.Writer, format Format, m Mapping, facts []gomind.Fact) error {
+	tw, err := NewWriter(w, format, m)
+	if err != nil {
+		return err
+	}
+	for _, f := range facts {
+		if err := tw.Write(m.Triple(f)); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+// ReadFacts reads every triple from r and converts it to a fact.
+func ReadFacts(r io.Reader, format Format, m Mapping) ([]gomind.Fact, error) {
+	tr, err := NewReader(r, format)
+	if err != nil {
+		return nil, err
+	}
+	var facts []gomind.Fact
+	for {
+		t, err := tr.Read()
+		if errors.Is(err, io.EOF) {
+			return facts, nil
+		}
+		if err != nil {
+			return facts, err
+		}
+		f, err := m.Fact(t)
+		if err != nil {
+			return facts, err
+		}
+		facts = append(facts, f)
+	}
+}
+
+type ntriplesWriter struct {
+	w io.Writer
+}
+
+func (nw *ntriplesWriter) Write(t Triple) error {
+	_, err := io.WriteString(nw.w, t.String()+"\n")
+	return err
+}
+
+func (nw *ntriplesWriter) Close() error { return nil }
+
+func escapeString(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '\\':
+			b.WriteString(`\\`)
+		case '"':
+			b.WriteString(`\"`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	return b.String()
+}
+
+func escapeIRI(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r <= 0x20 || strings.ContainsRune("<>\"{}|^`\\", r) {
+			fmt.Fprintf(&b, `\u%04X`, r)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,103 @@
+package rdf
+
+import (
+	"cmp"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	gomind "github.com/ingate/gomind-go-sdk"
+)
+
+var testFacts = []gomind.Fact{
+	{Subject: "John Smith", Predicate: "works_at", Object: "Acme"},
+	{Subject: "John Smith", Predicate: "age", Value: "42"},
+	{Subject: "John Smith", Predicate: "type", Object: "Person"},
+	{Subject: "Acme", Predicate: "foaf:name", Value: `Acme "Inc"`},
+	{Subject: "_:x", Predicate: "knows", Object: "https://example.org/people/jane"},
+}
+
+var testMapping = Mapping{
+	Base:       "https://example.org/id/",
+	Namespaces: map[string]string{"foaf": "http://xmlns.com/foaf/0.1/"},
+	Predicates: map[string]string{"works_at": "http://schema.org/worksFor"},
+}
+
+func TestMappingTriple(t *testing.T) {
+	got := testMapping.Triple(testFacts[0]).String()
+	want := `<https://example.org/id/John%20Smith> <http://schema.org/worksFor> <https://example.org/id/Acme> .`
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	for _, tc := range []struct {
+		fact gomind.Fact
+		want string
+	}{
+		{testFacts[1], `<https://example.org/id/John%20Smith> <urn:gomind:predicate:age> "42" .`},
+		{testFacts[2], `<https://example.org/id/John%20Smith> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <https://example.org/id/Person> .`},
+		{testFacts[3], `<https://example.org/id/Acme> <http://xmlns.com/foaf/0.1/name> "Acme \"Inc\"" .`},
+		{testFacts[4], `_:x <urn:gomind:predicate:knows> <https://example.org/people/jane> .`},
+	} {
+		if got := testMapping.Triple(tc.fact).String(); got != tc.want {
+			t.Errorf("got %s, want %s", got, tc.want)
+		}
+	}
+}
+
+func TestMappingRemember(t *testing.T) {
+	req, err := Mapping{}.Remember(Mapping{}.Triple(gomind.Fact{Subject: "John", Predicate: "age", Value: "42"}))
+	if err != nil {
+		t.Fatalf("Remember: %v", err)
+	}
+	if req != (gomind.RememberRequest{Subject: "John", Predicate: "age", Object: "42"}) {
+		t.Errorf("unexpected request %+v", req)
+	}
+
+	tr := Mapping{}.RememberTriple(gomind.RememberRequest{Subject: "John", Predicate: "likes", Object: "Tea"})
+	if tr.Object != NewIRI(DefaultBase+"Tea") {
+		t.Errorf("expected a resource object, got %+v", tr.Object)
+	}
+
+	if _, err := (Mapping{}).Fact(Triple{Subject: NewLiteral("x"), Predicate: NewIRI("p"), Object: NewLiteral("y")}); err == nil {
+		t.Error("expected a literal subject to fail")
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, format := range []Format{NTriples, Turtle, JSONLD} {
+		t.Run(string(format), func(t *testing.T) {
+			var b strings.Builder
+			if err := WriteFacts(&b, format, testMapping, testFacts); err != nil {
+				t.Fatalf("WriteFacts: %v", err)
+			}
+			facts, err := ReadFacts(strings.NewReader(b.String()), format, testMapping)
+			if err != nil {
+				t.Fatalf("ReadFacts: %v\n%s", err, b.String())
+			}
+			want := slices.Clone(testFacts)
+			if format == JSONLD {
+				// JSON-LD node objects do not keep property order.
+				byPredicate := func(a, b gomind.Fact) int {
+					return cmp.Or(cmp.Compare(a.Subject, b.Subject), cmp.Compare(a.Predicate, b.Predicate))
+				}
+				slices.SortFunc(facts, byPredicate)
+				slices.SortFunc(want, byPredicate)
+			}
+			if !reflect.DeepEqual(facts, want) {
+				t.Errorf("round trip changed facts:\n got %+v\nwant %+v\n%s", facts, want, b.String())
+			}
+		})
+	}
+}
+
+func TestNTriplesSyntaxError(t *testing.T) {
+	input := "<a> <b> <c> .\n<a> <b> \"unterminated .\n"
+	_, err := ReadFacts(strings.NewReader(input), NTriples, Mapping{})
+	var syntax *SyntaxError
+	if !errors.As(err, &syntax) || syntax.Line != 2 {
+		t.Errorf("expected a syntax error on line 2, got %v", err)
+	}
+}
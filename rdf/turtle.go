@@ -0,0 +1,705 @@
+package rdf
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// tokenKind identifies a Turtle token.
+type tokenKind int
+
+const (
+	tokEOF      tokenKind = iota
+	tokIRI                // <...>, text is the unescaped IRI
+	tokName               // prefixed name, keyword or boolean
+	tokBlank              // _:label, text is the label
+	tokString             // text is the unescaped string
+	tokLang               // @tag or @prefix/@base
+	tokNumber             // integer, decimal or double
+	tokDatatype           // ^^
+	tokPunct              // . ; , [ ] ( )
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	line int
+}
+
+// lexer splits Turtle (and therefore N-Triples) into tokens, reading
+// the input incrementally.
+type lexer struct {
+	r       *bufio.Reader
+	line    int
+	start   int // line of the token being read
+	pending []token
+}
+
+func (l *lexer) errorf(format string, args ...any) error {
+	return &SyntaxError{Line: l.start, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (l *lexer) peek() rune {
+	for n := 1; n <= utf8.UTFMax; n++ {
+		b, err := l.r.Peek(n)
+		if len(b) == 0 {
+			return -1
+		}
+		if r, _ := utf8.DecodeRune(b); r != utf8.RuneError || err != nil {
+			return r
+		}
+	}
+	return utf8.RuneError
+}
+
+// peekAt returns the ASCII byte n bytes ahead, or 0.
+func (l *lexer) peekAt(n int) byte {
+	b, _ := l.r.Peek(n + 1)
+	if len(b) <= n {
+		return 0
+	}
+	return b[n]
+}
+
+func (l *lexer) next() rune {
+	r, _, err := l.r.ReadRune()
+	if err != nil {
+		return -1
+	}
+	if r == '\n' {
+		l.line++
+	}
+	return r
+}
+
+func (l *lexer) token() (token, error) {
+	if len(l.pending) > 0 {
+		t := l.pending[0]
+		l.pending = l.pending[1:]
+		return t, nil
+	}
+
+	// Skip whitespace and comments.
+	for {
+		r := l.peek()
+		if r == '#' {
+			for r != '\n' && r != -1 {
+				r = l.next()
+			}
+			continue
+		}
+		if r == -1 || !unicode.IsSpace(r) {
+			break
+		}
+		l.next()
+	}
+
+	line := l.line
+	l.start = line
+	tok := func(kind tokenKind, text string) (token, error) {
+		return token{kind: kind, text: text, line: line}, nil
+	}
+
+	r := l.peek()
+	switch {
+	case r == -1:
+		return tok(tokEOF, "")
+	case r == '<':
+		l.next()
+		iri, err := l.readIRI()
+		if err != nil {
+			return token{}, err
+		}
+		return tok(tokIRI, iri)
+	case r == '"' || r == '\'':
+		s, err := l.readString()
+		if err != nil {
+			return token{}, err
+		}
+		return tok(tokString, s)
+	case r == '@':
+		l.next()
+		return tok(tokLang, l.readWhile(func(r rune) bool { return r == '-' || isAlnum(r) }))
+	case r == '^':
+		l.next()
+		if l.next() != '^' {
+			return token{}, l.errorf("expected ^^")
+		}
+		return tok(tokDatatype, "^^")
+	case r == '_' && l.peekAt(1) == ':':
+		l.next()
+		l.next()
+		return l.nameToken(tokBlank, line)
+	case r == '.' && isDigit(rune(l.peekAt(1))), r == '+', r == '-', isDigit(r):
+		number := l.readWhile(func(r rune) bool {
+			return isDigit(r) || strings.ContainsRune("+-.eE", r)
+		})
+		return tok(tokNumber, l.trimDots(number))
+	case strings.ContainsRune(".;,[]()", r):
+		l.next()
+		return tok(tokPunct, string(r))
+	case r == ':' || isNameChar(r):
+		return l.nameToken(tokName, line)
+	}
+	return token{}, l.errorf("unexpected character %q", r)
+}
+
+// nameToken reads a prefixed name or blank node label. A trailing dot
+// ends the statement rather than belonging to the name.
+func (l *lexer) nameToken(kind tokenKind, line int) (token, error) {
+	var b strings.Builder
+	for {
+		r := l.peek()
+		if r == '\\' {
+			l.next()
+			b.WriteRune(l.next())
+			continue
+		}
+		if r != ':' && r != '%' && !isNameChar(r) {
+			break
+		}
+		b.WriteRune(l.next())
+	}
+	name := l.trimDots(b.String())
+	if name == "" {
+		return token{}, l.errorf("empty name")
+	}
+	return token{kind: kind, text: name, line: line}, nil
+}
+
+// trimDots removes trailing dots from s and queues them as statement
+// terminators.
+func (l *lexer) trimDots(s string) string {
+	trimmed := strings.TrimRight(s, ".")
+	for range len(s) - len(trimmed) {
+		l.pending = append(l.pending, token{kind: tokPunct, text: ".", line: l.line})
+	}
+	return trimmed
+}
+
+func (l *lexer) readWhile(ok func(rune) bool) string {
+	var b strings.Builder
+	for r := l.peek(); r != -1 && ok(r); r = l.peek() {
+		b.WriteRune(l.next())
+	}
+	return b.String()
+}
+
+func (l *lexer) readIRI() (string, error) {
+	var b strings.Builder
+	for {
+		switch r := l.next(); r {
+		case -1, '\n':
+			return "", l.errorf("unterminated IRI")
+		case '>':
+			return b.String(), nil
+		case '\\':
+			r, err := l.readEscape()
+			if err != nil {
+				return "", err
+			}
+			b.WriteRune(r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+}
+
+func (l *lexer) readString() (string, error) {
+	quote := l.next()
+	long := l.peekAt(0) == byte(quote) && l.peekAt(1) == byte(quote)
+	if long {
+		l.next()
+		l.next()
+	}
+	var b strings.Builder
+	for {
+		r := l.next()
+		switch {
+		case r == -1 || (r == '\n' && !long):
+			return "", l.errorf("unterminated string")
+		case r == quote && !long:
+			return b.String(), nil
+		case r == quote && l.peekAt(0) == byte(quote) && l.peekAt(1) == byte(quote):
+			l.next()
+			l.next()
+			return b.String(), nil
+		case r == '\\':
+			r, err := l.readEscape()
+			if err != nil {
+				return "", err
+			}
+			b.WriteRune(r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+}
+
+func (l *lexer) readEscape() (rune, error) {
+	r := l.next()
+	switch r {
+	case 't':
+		return '\t', nil
+	case 'b':
+		return '\b', nil
+	case 'n':
+		return '\n', nil
+	case 'r':
+		return '\r', nil
+	case 'f':
+		return '\f', nil
+	case '"', '\'', '\\':
+		return r, nil
+	case 'u', 'U':
+		n := 4
+		if r == 'U' {
+			n = 8
+		}
+		var hex strings.Builder
+		for range n {
+			hex.WriteRune(l.next())
+		}
+		v, err := strconv.ParseUint(hex.String(), 16, 32)
+		if err != nil {
+			return 0, l.errorf("invalid escape \\%c%s", r, hex.String())
+		}
+		return rune(v), nil
+	}
+	return 0, l.errorf("invalid escape \\%c", r)
+}
+
+func isDigit(r rune) bool { return r >= '0' && r <= '9' }
+
+func isAlnum(r rune) bool { return r < utf8.RuneSelf && (isDigit(r) || unicode.IsLetter(r)) }
+
+func isNameChar(r rune) bool {
+	return r == '_' || r == '-' || r == '.' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// turtleReader parses Turtle, a superset of N-Triples. It supports
+// prefix and base directives, prefixed names, "a", predicate and object
+// lists, blank node property lists and typed or language-tagged
+// literals. Collections are not supported.
+type turtleReader struct {
+	lex      lexer
+	base     *url.URL
+	prefixes map[string]string
+	queue    []Triple
+	blanks   int
+	tok      *token
+}
+
+func newTurtleReader(r io.Reader) *turtleReader {
+	return &turtleReader{
+		lex:      lexer{r: bufio.NewReader(r), line: 1},
+		prefixes: make(map[string]string),
+	}
+}
+
+func (p *turtleReader) Read() (Triple, error) {
+	for len(p.queue) == 0 {
+		t, err := p.peekToken()
+		if err != nil {
+			return Triple{}, err
+		}
+		if t.kind == tokEOF {
+			return Triple{}, io.EOF
+		}
+		if err := p.statement(); err != nil {
+			return Triple{}, err
+		}
+	}
+	t := p.queue[0]
+	p.queue = p.queue[1:]
+	return t, nil
+}
+
+func (p *turtleReader) peekToken() (token, error) {
+	if p.tok == nil {
+		t, err := p.lex.token()
+		if err != nil {
+			return token{}, err
+		}
+		p.tok = &t
+	}
+	return *p.tok, nil
+}
+
+func (p *turtleReader) nextToken() (token, error) {
+	t, err := p.peekToken()
+	p.tok = nil
+	return t, err
+}
+
+func (p *turtleReader) errorf(t token, format string, args ...any) error {
+	return &SyntaxError{Line: t.line, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *turtleReader) expect(text string) error {
+	t, err := p.nextToken()
+	if err != nil {
+		return err
+	}
+	if t.kind != tokPunct || t.text != text {
+		return p.errorf(t, "expected %q, got %q", text, t.text)
+	}
+	return nil
+}
+
+func (p *turtleReader) statement() error {
+	t, err := p.peekToken()
+	if err != nil {
+		return err
+	}
+	switch {
+	case t.kind == tokLang && (t.text == "prefix" || t.text == "base"):
+		p.nextToken()
+		if err := p.directive(t.text); err != nil {
+			return err
+		}
+		return p.expect(".")
+	case t.kind == tokName && (strings.EqualFold(t.text, "prefix") || strings.EqualFold(t.text, "base")):
+		p.nextToken()
+		return p.directive(strings.ToLower(t.text))
+	}
+
+	subject, err := p.subject()
+	if err != nil {
+		return err
+	}
+	// "[ ... ] ." is a complete statement on its own.
+	next, err := p.peekToken()
+	if err != nil {
+		return err
+	}
+	isPropertyList := t.kind == tokPunct && t.text == "["
+	if !isPropertyList || next.kind != tokPunct || next.text != "." {
+		if err := p.predicateObjectList(subject); err != nil {
+			return err
+		}
+	}
+	return p.expect(".")
+}
+
+func (p *turtleReader) directive(kind string) error {
+	if kind == "prefix" {
+		name, err := p.nextToken()
+		if err != nil {
+			return err
+		}
+		prefix, ok := strings.CutSuffix(name.text, ":")
+		if name.kind != tokName || !ok {
+			return p.errorf(name, "expected a prefix name, got %q", name.text)
+		}
+		iri, err := p.iriToken()
+		if err != nil {
+			return err
+		}
+		p.prefixes[prefix] = iri
+		return nil
+	}
+	iri, err := p.iriToken()
+	if err != nil {
+		return err
+	}
+	if p.base, err = url.Parse(iri); err != nil {
+		return p.errorf(token{line: p.lex.line}, "invalid base %q", iri)
+	}
+	return nil
+}
+
+func (p *turtleReader) iriToken() (string, error) {
+	t, err := p.nextToken()
+	if err != nil {
+		return "", err
+	}
+	if t.kind != tokIRI {
+		return "", p.errorf(t, "expected an IRI, got %q", t.text)
+	}
+	return p.resolve(t.text), nil
+}
+
+func (p *turtleReader) resolve(iri string) string {
+	if p.base == nil {
+		return iri
+	}
+	ref, err := url.Parse(iri)
+	if err != nil || ref.IsAbs() {
+		return iri
+	}
+	return p.base.ResolveReference(ref).String()
+}
+
+func (p *turtleReader) prefixed(t token) (string, error) {
+	prefix, local, ok := strings.Cut(t.text, ":")
+	if !ok {
+		return "", p.errorf(t, "unexpected %q", t.text)
+	}
+	ns, ok := p.prefixes[prefix]
+	if !ok {
+		return "", p.errorf(t, "undeclared prefix %q", prefix)
+	}
+	return ns + local, nil
+}
+
+func (p *turtleReader) newBlank() Term {
+	p.blanks++
+	return NewBlank("b" + strconv.Itoa(p.blanks))
+}
+
+func (p *turtleReader) subject() (Term, error) {
+	t, err := p.nextToken()
+	if err != nil {
+		return Term{}, err
+	}
+	switch t.kind {
+	case tokIRI:
+		return NewIRI(p.resolve(t.text)), nil
+	case tokBlank:
+		return NewBlank(t.text), nil
+	case tokName:
+		iri, err := p.prefixed(t)
+		return NewIRI(iri), err
+	case tokPunct:
+		if t.text == "[" {
+			return p.blankPropertyList()
+		}
+	}
+	return Term{}, p.errorf(t, "unexpected %q in subject", t.text)
+}
+
+// blankPropertyList parses the rest of "[ ... ]" and returns the new
+// blank node.
+func (p *turtleReader) blankPropertyList() (Term, error) {
+	node := p.newBlank()
+	if t, err := p.peekToken(); err != nil {
+		return Term{}, err
+	} else if t.kind == tokPunct && t.text == "]" {
+		p.nextToken()
+		return node, nil
+	}
+	if err := p.predicateObjectList(node); err != nil {
+		return Term{}, err
+	}
+	return node, p.expect("]")
+}
+
+func (p *turtleReader) predicateObjectList(subject Term) error {
+	for {
+		predicate, err := p.verb()
+		if err != nil {
+			return err
+		}
+		for {
+			object, err := p.object()
+			if err != nil {
+				return err
+			}
+			p.queue = append(p.queue, Triple{Subject: subject, Predicate: predicate, Object: object})
+			if t, err := p.peekToken(); err != nil {
+				return err
+			} else if t.kind != tokPunct || t.text != "," {
+				break
+			}
+			p.nextToken()
+		}
+
+		// Any number of semicolons, optionally followed by another verb.
+		t, err := p.peekToken()
+		if err != nil {
+			return err
+		}
+		if t.kind != tokPunct || t.text != ";" {
+			return nil
+		}
+		for t.kind == tokPunct && t.text == ";" {
+			p.nextToken()
+			if t, err = p.peekToken(); err != nil {
+				return err
+			}
+		}
+		if t.kind == tokPunct && (t.text == "." || t.text == "]") {
+			return nil
+		}
+	}
+}
+
+func (p *turtleReader) verb() (Term, error) {
+	t, err := p.nextToken()
+	if err != nil {
+		return Term{}, err
+	}
+	switch {
+	case t.kind == tokIRI:
+		return NewIRI(p.resolve(t.text)), nil
+	case t.kind == tokName && t.text == "a":
+		return NewIRI(RDFType), nil
+	case t.kind == tokName:
+		iri, err := p.prefixed(t)
+		return NewIRI(iri), err
+	}
+	return Term{}, p.errorf(t, "unexpected %q in predicate", t.text)
+}
+
+func (p *turtleReader) object() (Term, error) {
+	t, err := p.nextToken()
+	if err != nil {
+		return Term{}, err
+	}
+	switch t.kind {
+	case tokIRI:
+		return NewIRI(p.resolve(t.text)), nil
+	case tokBlank:
+		return NewBlank(t.text), nil
+	case tokString:
+		return p.literal(t.text)
+	case tokNumber:
+		datatype := XSDNamespace + "integer"
+		switch {
+		case strings.ContainsAny(t.text, "eE"):
+			datatype = XSDNamespace + "double"
+		case strings.Contains(t.text, "."):
+			datatype = XSDNamespace + "decimal"
+		}
+		return Term{Kind: Literal, Value: t.text, Datatype: datatype}, nil
+	case tokName:
+		if t.text == "true" || t.text == "false" {
+			return Term{Kind: Literal, Value: t.text, Datatype: XSDNamespace + "boolean"}, nil
+		}
+		iri, err := p.prefixed(t)
+		return NewIRI(iri), err
+	case tokPunct:
+		switch t.text {
+		case "[":
+			return p.blankPropertyList()
+		case "(":
+			return Term{}, p.errorf(t, "collections are not supported")
+		}
+	}
+	return Term{}, p.errorf(t, "unexpected %q in object", t.text)
+}
+
+func (p *turtleReader) literal(value string) (Term, error) {
+	lit := NewLiteral(value)
+	t, err := p.peekToken()
+	if err != nil {
+		return Term{}, err
+	}
+	switch t.kind {
+	case tokLang:
+		p.nextToken()
+		lit.Language = t.text
+	case tokDatatype:
+		p.nextToken()
+		dt, err := p.nextToken()
+		if err != nil {
+			return Term{}, err
+		}
+		switch dt.kind {
+		case tokIRI:
+			lit.Datatype = p.resolve(dt.text)
+		case tokName:
+			if lit.Datatype, err = p.prefixed(dt); err != nil {
+				return Term{}, err
+			}
+		default:
+			return Term{}, p.errorf(dt, "expected a datatype, got %q", dt.text)
+		}
+	}
+	return lit, nil
+}
+
+// turtleWriter writes Turtle, grouping consecutive triples that share a
+// subject (and predicate) into predicate and object lists.
+type turtleWriter struct {
+	w       *bufio.Writer
+	m       Mapping
+	started bool
+	subject *Term
+	pred    string
+}
+
+func newTurtleWriter(w io.Writer, m Mapping) *turtleWriter {
+	return &turtleWriter{w: bufio.NewWriter(w), m: m}
+}
+
+func (tw *turtleWriter) header() {
+	tw.started = true
+	for _, prefix := range sortedKeys(tw.m.Namespaces) {
+		fmt.Fprintf(tw.w, "@prefix %s: <%s> .\n", prefix, escapeIRI(tw.m.Namespaces[prefix]))
+	}
+	if len(tw.m.Namespaces) > 0 {
+		fmt.Fprintln(tw.w)
+	}
+}
+
+func (tw *turtleWriter) Write(t Triple) error {
+	if !tw.started {
+		tw.header()
+	}
+	pred := tw.term(t.Predicate)
+	if t.Predicate.Value == RDFType {
+		pred = "a"
+	}
+	switch {
+	case tw.subject != nil && *tw.subject == t.Subject && tw.pred == pred:
+		fmt.Fprintf(tw.w, ", %s", tw.term(t.Object))
+	case tw.subject != nil && *tw.subject == t.Subject:
+		fmt.Fprintf(tw.w, " ;\n    %s %s", pred, tw.term(t.Object))
+	default:
+		if tw.subject != nil {
+			fmt.Fprintln(tw.w, " .")
+		}
+		fmt.Fprintf(tw.w, "%s %s %s", tw.term(t.Subject), pred, tw.term(t.Object))
+	}
+	tw.subject = &t.Subject
+	tw.pred = pred
+	return nil
+}
+
+func (tw *turtleWriter) Close() error {
+	if !tw.started {
+		tw.header()
+	}
+	if tw.subject != nil {
+		fmt.Fprintln(tw.w, " .")
+		tw.subject = nil
+	}
+	return tw.w.Flush()
+}
+
+// term returns t in Turtle syntax, using a declared prefix when the
+// local part is a plain name.
+func (tw *turtleWriter) term(t Term) string {
+	if t.Kind == Literal && t.Datatype != "" && t.Datatype != XSDString && t.Language == "" {
+		return `"` + escapeString(t.Value) + `"^^` + tw.term(NewIRI(t.Datatype))
+	}
+	if t.Kind != IRI {
+		return t.String()
+	}
+	for _, prefix := range sortedKeys(tw.m.Namespaces) {
+		if local, ok := strings.CutPrefix(t.Value, tw.m.Namespaces[prefix]); ok && isLocalName(local) {
+			return prefix + ":" + local
+		}
+	}
+	return t.String()
+}
+
+func isLocalName(s string) bool {
+	if s == "" || strings.HasSuffix(s, ".") || strings.HasPrefix(s, ".") || strings.HasPrefix(s, "-") {
+		return false
+	}
+	for _, r := range s {
+		if r != '_' && r != '-' && r != '.' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
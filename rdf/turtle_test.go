@@ -0,0 +1,86 @@
+package rdf
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func readAll(t *testing.T, input string, format Format) []string {
+	t.Helper()
+	r, err := NewReader(strings.NewReader(input), format)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	var lines []string
+	for {
+		tr, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return lines
+		}
+		if err != nil {
+			t.Fatalf("Read: %v", err)
+		}
+		lines = append(lines, tr.String())
+	}
+}
+
+func TestTurtleReader(t *testing.T) {
+	input := `
+@base <http://example.org/> .
+@prefix ex: <http://example.org/ns#> .
+PREFIX foaf: <http://xmlns.com/foaf/0.1/>
+
+# John has a few facts.
+<john> a foaf:Person ;
+    foaf:name "John"@en, 'Johnny' ;
+    ex:age 42 ;
+    ex:height 1.8 ;
+    ex:active true ;
+    ex:bio """line one
+line two""" ;
+    ex:born "1980-01-01"^^<http://www.w3.org/2001/XMLSchema#date> ;
+    ex:knows [ foaf:name "Jane" ] .
+ex:acme ex:employs <john>.
+`
+	want := []string{
+		`<http://example.org/john> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://xmlns.com/foaf/0.1/Person> .`,
+		`<http://example.org/john> <http://xmlns.com/foaf/0.1/name> "John"@en .`,
+		`<http://example.org/john> <http://xmlns.com/foaf/0.1/name> "Johnny" .`,
+		`<http://example.org/john> <http://example.org/ns#age> "42"^^<http://www.w3.org/2001/XMLSchema#integer> .`,
+		`<http://example.org/john> <http://example.org/ns#height> "1.8"^^<http://www.w3.org/2001/XMLSchema#decimal> .`,
+		`<http://example.org/john> <http://example.org/ns#active> "true"^^<http://www.w3.org/2001/XMLSchema#boolean> .`,
+		`<http://example.org/john> <http://example.org/ns#bio> "line one\nline two" .`,
+		`<http://example.org/john> <http://example.org/ns#born> "1980-01-01"^^<http://www.w3.org/2001/XMLSchema#date> .`,
+		`_:b1 <http://xmlns.com/foaf/0.1/name> "Jane" .`,
+		`<http://example.org/john> <http://example.org/ns#knows> _:b1 .`,
+		`<http://example.org/ns#acme> <http://example.org/ns#employs> <http://example.org/john> .`,
+	}
+	if got := readAll(t, input, Turtle); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected triples:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestTurtleReaderErrors(t *testing.T) {
+	for _, input := range []string{
+		"ex:a ex:b ex:c .",
+		"<a> <b> (<c>) .",
+		"<a> <b> <c>",
+		"<a> <b> \"x\\q\" .",
+	} {
+		r, _ := NewReader(strings.NewReader(input), Turtle)
+		if _, err := r.Read(); err == nil || errors.Is(err, io.EOF) {
+			t.Errorf("%q: expected a syntax error, got %v", input, err)
+		}
+	}
+}
+
+func TestNTriplesEscapes(t *testing.T) {
+	input := `<http://ex/s> <http://ex/p> "tab\there é \U0001F600 \"q\"" .` + "\n"
+	got := readAll(t, input, NTriples)
+	if len(got) != 1 || !strings.Contains(got[0], `"tab\there é 😀 \"q\""`) {
+		t.Errorf("unexpected triples %v", got)
+	}
+}
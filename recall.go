@@ -1,9 +1,12 @@
 package gomind
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"time"
 )
 
 // Recall searches for facts in the knowledge graph.
@@ -37,6 +40,12 @@ func (c *Client) RecallWithOptions(ctx context.Context, req RecallRequest) (*Rec
 	if err := json.Unmarshal(respBody, &resp); err != nil {
 		return nil, fmt.Errorf("failed to parse recall response: %w", err)
 	}
+	if req.AsOf != nil {
+		// Also filter locally so facts outside the interval are dropped
+		// even if the server ignores as_of.
+		resp.Result.Facts = slices.DeleteFunc(resp.Result.Facts, func(f Fact) bool { return !f.ValidAt(*req.AsOf) })
+		resp.Result.Count = len(resp.Result.Facts)
+	}
 
 	c.logger.Info("Gomind Recall success",
 		"query", req.Query,
@@ -88,11 +97,29 @@ type factRow struct {
 	Object    string `json:"object"`
 }
 
+// FactContextOptions configures FormatFactsAsContextWithOptions.
+type FactContextOptions struct {
+	// TimeColumns adds valid_from, valid_to and observed_at columns, each
+	// only when at least one fact has that time.
+	TimeColumns bool
+	// TimeLayout formats the time columns. Defaults to time.RFC3339.
+	TimeLayout string
+}
+
 // FormatFactsAsContext formats recalled facts as a context string for the LLM.
 func FormatFactsAsContext(facts []Fact) string {
+	return FormatFactsAsContextWithOptions(facts, FactContextOptions{})
+}
+
+// FormatFactsAsContextWithOptions is like FormatFactsAsContext with
+// optional time columns, e.g. to render a History timeline.
+func FormatFactsAsContextWithOptions(facts []Fact, opts FactContextOptions) string {
 	if len(facts) == 0 {
 		return ""
 	}
+	if opts.TimeColumns {
+		return formatTimedFacts(facts, cmp.Or(opts.TimeLayout, time.RFC3339))
+	}
 
 	// Build rows from valid facts
 	rows := make([]factRow, 0, len(facts))
@@ -121,3 +148,40 @@ func getObjectValue(fact Fact) string {
 	}
 	return fact.Value
 }
+
+// formatTimedFacts encodes facts with the time columns that have values.
+func formatTimedFacts(facts []Fact, layout string) string {
+	fields := []string{"subject", "predicate", "object"}
+	timeFields := []struct {
+		name string
+		get  func(Fact) *time.Time
+	}{
+		{"valid_from", func(f Fact) *time.Time { return f.ValidFrom }},
+		{"valid_to", func(f Fact) *time.Time { return f.ValidTo }},
+		{"observed_at", func(f Fact) *time.Time { return f.ObservedAt }},
+	}
+	for _, tf := range timeFields {
+		if slices.ContainsFunc(facts, func(f Fact) bool { return tf.get(f) != nil }) {
+			fields = append(fields, tf.name)
+		}
+	}
+
+	var rows []map[string]string
+	for _, fact := range facts {
+		object := getObjectValue(fact)
+		if fact.Subject == "" || object == "" {
+			continue
+		}
+		row := map[string]string{"subject": fact.Subject, "predicate": fact.Predicate, "object": object}
+		for _, tf := range timeFields {
+			if t := tf.get(fact); t != nil {
+				row[tf.name] = t.Format(layout)
+			}
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return ""
+	}
+	return EncodeTabular("memory", rows, fields...)
+}
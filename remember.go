@@ -20,6 +20,30 @@ func (c *Client) Remember(ctx context.Context, subject, predicate, object string
 // RememberWithOptions stores a single fact with full control over request parameters.
 // Use the Normalize field to enable LLM-based normalization of abbreviations.
 func (c *Client) RememberWithOptions(ctx context.Context, req RememberRequest) (*RememberResponse, error) {
+	return c.rememberWithOntology(ctx, req, true)
+}
+
+// rememberWithOntology applies the ontology to req and stores it. upsert
+// enables Ontology.UpsertSingle; the remember tool turns it off when the
+// tool policy does not allow forget, since an upsert removes values.
+func (c *Client) rememberWithOntology(ctx context.Context, req RememberRequest, upsert bool) (*RememberResponse, error) {
+	if c.ontology != nil {
+		normalized, err := c.ontology.Normalize(req)
+		if err != nil {
+			c.logger.Error("Gomind Remember rejected", "error", err)
+			return nil, err
+		}
+		req = normalized
+		if upsert && c.ontology.UpsertSingle && c.ontology.single(req.Predicate) && req.ValidTo == nil {
+			resp, _, err := c.upsert(ctx, req)
+			return resp, err
+		}
+	}
+	return c.remember(ctx, req)
+}
+
+// remember posts a fact that has already been checked by the ontology.
+func (c *Client) remember(ctx context.Context, req RememberRequest) (*RememberResponse, error) {
 	req.Collection = c.resolveCollection(req.Collection)
 	respBody, err := c.post(ctx, "/v1/remember", req)
 	if err != nil {
@@ -52,6 +76,29 @@ func (c *Client) RememberMany(ctx context.Context, facts []RememberRequest, sour
 // RememberManyWithOptions stores multiple facts with full control over the
 // request payload, including the optional Collection field.
 func (c *Client) RememberManyWithOptions(ctx context.Context, req RememberManyRequest) error {
+	return c.rememberManyWithOntology(ctx, req, true)
+}
+
+// rememberManyWithOntology is the RememberMany counterpart of
+// rememberWithOntology.
+func (c *Client) rememberManyWithOntology(ctx context.Context, req RememberManyRequest, upsert bool) error {
+	if c.ontology != nil {
+		facts, err := c.ontology.NormalizeMany(req.Facts)
+		if err != nil {
+			c.logger.Error("Gomind RememberMany rejected", "error", err, "factCount", len(req.Facts))
+			return err
+		}
+		req.Facts = facts
+		if upsert && c.ontology.UpsertSingle {
+			_, err := c.upsertMany(ctx, req, c.ontology.single)
+			return err
+		}
+	}
+	return c.rememberMany(ctx, req)
+}
+
+// rememberMany posts facts that have already been checked by the ontology.
+func (c *Client) rememberMany(ctx context.Context, req RememberManyRequest) error {
 	req.Collection = c.resolveCollection(req.Collection)
 
 	_, err := c.post(ctx, "/v1/remember_many", req)
@@ -0,0 +1,460 @@
+package gomind
+
+import (
+	"context"
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxStructDepth bounds how deeply RememberStruct and RecallInto follow
+// nested structs, guarding against cycles.
+const maxStructDepth = 8
+
+// StructOptions configures RememberStructWithOptions and
+// RecallIntoWithOptions.
+type StructOptions struct {
+	// Collection scopes the facts. See RememberRequest.Collection for the
+	// *string semantics.
+	Collection *string
+
+	// Source and Context are attached to every remembered fact.
+	Source  string
+	Context string
+
+	// Depth is the recall_connections depth used by RecallInto.
+	// Defaults to the nesting depth of the target type, at most 3.
+	Depth int
+}
+
+// structField is a struct field mapped by a gomind tag.
+type structField struct {
+	index     []int
+	predicate string
+	omitEmpty bool
+	entity    bool
+}
+
+// structFields returns the tagged fields of t, including those of
+// untagged embedded structs, and the index of its subject field, or nil
+// if it has none.
+func structFields(t reflect.Type) ([]structField, []int, error) {
+	var fields []structField
+	var subject []int
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, tagged := f.Tag.Lookup("gomind")
+		if !tagged {
+			if f.Anonymous && f.Type.Kind() == reflect.Struct {
+				embedded, embeddedSubject, err := structFields(f.Type)
+				if err != nil {
+					return nil, nil, err
+				}
+				for _, ef := range embedded {
+					ef.index = append([]int{i}, ef.index...)
+					fields = append(fields, ef)
+				}
+				if embeddedSubject != nil && subject == nil {
+					subject = append([]int{i}, embeddedSubject...)
+				}
+			}
+			continue
+		}
+		if tag == "-" || !f.IsExported() {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		sf := structField{index: []int{i}, predicate: parts[0]}
+		isSubject := false
+		for _, opt := range parts[1:] {
+			switch opt {
+			case "omitempty":
+				sf.omitEmpty = true
+			case "entity":
+				sf.entity = true
+			case "subject":
+				isSubject = true
+			default:
+				return nil, nil, fmt.Errorf("field %s: unknown gomind tag option %q", f.Name, opt)
+			}
+		}
+		if isSubject {
+			if f.Type.Kind() != reflect.String {
+				return nil, nil, fmt.Errorf("field %s: subject field must be a string", f.Name)
+			}
+			subject = sf.index
+			continue
+		}
+		if sf.predicate == "" {
+			return nil, nil, fmt.Errorf("field %s: gomind tag has no predicate", f.Name)
+		}
+		fields = append(fields, sf)
+	}
+	return fields, subject, nil
+}
+
+var textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+
+// isNested reports whether t is stored as a related entity rather than
+// a literal.
+func isNested(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t != timeType && !reflect.PointerTo(t).Implements(textMarshalerType)
+}
+
+// StructFacts converts v, a struct or pointer to struct, into facts
+// about subject.
+//
+// Fields are mapped by a `gomind:"predicate,options"` tag; untagged
+// fields are ignored, except that the fields of embedded structs are
+// promoted. The options are:
+//
+//   - omitempty: skip zero values;
+//   - entity: the value names an entity, so FactsInto only reads facts
+//     whose Object is set;
+//   - subject: `gomind:",subject"` marks the string field holding the
+//     struct's own entity name. It is used when subject is empty and is
+//     required on nested structs.
+//
+// Each tagged field becomes one fact, or one fact per element for
+// slices and arrays. Strings, booleans, numbers, time.Time (RFC 3339)
+// and encoding.TextMarshaler values are stored as their text. Empty
+// strings and nil pointers are always skipped; omitempty also skips
+// other zero values. A nested struct is a related entity: the fact
+// links subject to the name in the nested struct's subject field, and
+// the nested struct's own facts follow.
+func StructFacts(subject string, v any) ([]RememberRequest, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, fmt.Errorf("struct value is nil")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("struct value must be a struct, got %s", rv.Type())
+	}
+
+	var facts []RememberRequest
+	if err := appendStructFacts(&facts, subject, rv, 0); err != nil {
+		return nil, err
+	}
+	return facts, nil
+}
+
+func appendStructFacts(facts *[]RememberRequest, subject string, rv reflect.Value, depth int) error {
+	if depth > maxStructDepth {
+		return fmt.Errorf("structs nested deeper than %d", maxStructDepth)
+	}
+	fields, subjectIndex, err := structFields(rv.Type())
+	if err != nil {
+		return err
+	}
+	if subject == "" && subjectIndex != nil {
+		subject = rv.FieldByIndex(subjectIndex).String()
+	}
+	if subject == "" {
+		return fmt.Errorf("%s has no subject", rv.Type())
+	}
+
+	// Nested structs are expanded after the subject's own facts.
+	type nestedStruct struct {
+		subject string
+		value   reflect.Value
+	}
+	var nested []nestedStruct
+
+	for _, f := range fields {
+		fv := rv.FieldByIndex(f.index)
+		if f.omitEmpty && fv.IsZero() {
+			continue
+		}
+		values := []reflect.Value{fv}
+		if fv.Kind() == reflect.Slice || fv.Kind() == reflect.Array {
+			values = values[:0]
+			for i := 0; i < fv.Len(); i++ {
+				values = append(values, fv.Index(i))
+			}
+		}
+
+		for _, v := range values {
+			for v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					break
+				}
+				v = v.Elem()
+			}
+			if v.Kind() == reflect.Ptr {
+				continue
+			}
+
+			var object string
+			if isNested(v.Type()) {
+				_, nestedSubject, err := structFields(v.Type())
+				if err != nil {
+					return err
+				}
+				if nestedSubject == nil {
+					return fmt.Errorf("field %s: nested %s needs a `gomind:\",subject\"` field", f.predicate, v.Type())
+				}
+				object = v.FieldByIndex(nestedSubject).String()
+				if object != "" {
+					nested = append(nested, nestedStruct{object, v})
+				}
+			} else if object, err = formatStructValue(v); err != nil {
+				return fmt.Errorf("field %s: %w", f.predicate, err)
+			}
+			if object == "" {
+				continue
+			}
+			*facts = append(*facts, RememberRequest{Subject: subject, Predicate: f.predicate, Object: object})
+		}
+	}
+
+	for _, n := range nested {
+		if err := appendStructFacts(facts, n.subject, n.value, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func formatStructValue(v reflect.Value) (string, error) {
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return "", nil
+		}
+		return t.Format(time.RFC3339), nil
+	}
+	if v.CanAddr() && v.Addr().Type().Implements(textMarshalerType) {
+		v = v.Addr()
+	}
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		return string(text), err
+	}
+	v = reflect.Indirect(v)
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	}
+	return "", fmt.Errorf("unsupported type %s", v.Type())
+}
+
+// RememberStruct stores the facts StructFacts derives from v in a
+// single remember_many request.
+func (c *Client) RememberStruct(ctx context.Context, subject string, v any) error {
+	return c.RememberStructWithOptions(ctx, subject, v, StructOptions{})
+}
+
+// RememberStructWithOptions is RememberStruct with a collection, source
+// and context for the stored facts.
+func (c *Client) RememberStructWithOptions(ctx context.Context, subject string, v any, opts StructOptions) error {
+	facts, err := StructFacts(subject, v)
+	if err != nil {
+		return fmt.Errorf("failed to map struct to facts: %w", err)
+	}
+	if len(facts) == 0 {
+		return nil
+	}
+	for i := range facts {
+		facts[i].Context = opts.Context
+	}
+	return c.RememberManyWithOptions(ctx, RememberManyRequest{
+		Facts:      facts,
+		Source:     opts.Source,
+		Collection: opts.Collection,
+	})
+}
+
+// RecallInto recalls the facts about subject and its connections and
+// populates v, a pointer to a struct, with FactsInto.
+func (c *Client) RecallInto(ctx context.Context, subject string, v any) error {
+	return c.RecallIntoWithOptions(ctx, subject, v, StructOptions{})
+}
+
+// RecallIntoWithOptions is RecallInto with a collection and an explicit
+// recall depth.
+func (c *Client) RecallIntoWithOptions(ctx context.Context, subject string, v any, opts StructOptions) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("RecallInto target must be a non-nil pointer to a struct, got %T", v)
+	}
+	if opts.Depth <= 0 {
+		opts.Depth = min(structDepth(rv.Elem().Type(), 0), 3)
+	}
+
+	resp, err := c.RecallConnectionsWithOptions(ctx, RecallConnectionsRequest{
+		Entity:     subject,
+		Depth:      opts.Depth,
+		Collection: opts.Collection,
+	})
+	if err != nil {
+		return err
+	}
+	return FactsInto(subject, resp.Facts, v)
+}
+
+// structDepth returns how many entity hops t spans: 1 for a flat
+// struct, plus one per level of nested structs.
+func structDepth(t reflect.Type, level int) int {
+	fields, _, err := structFields(t)
+	if err != nil || level >= maxStructDepth {
+		return 1
+	}
+	depth := 1
+	for _, f := range fields {
+		ft := t.FieldByIndex(f.index).Type
+		for ft.Kind() == reflect.Ptr || ft.Kind() == reflect.Slice || ft.Kind() == reflect.Array {
+			ft = ft.Elem()
+		}
+		if isNested(ft) {
+			depth = max(depth, 1+structDepth(ft, level+1))
+		}
+	}
+	return depth
+}
+
+// FactsInto populates v, a pointer to a struct, from the facts about
+// subject. It is the inverse of StructFacts: each tagged field is set
+// from the facts with its predicate, parsing the text into the field's
+// type. Scalar fields take the first matching fact and slices take all
+// of them. Nested structs are filled from the facts about the related
+// entity. Facts for unknown predicates are ignored.
+func FactsInto(subject string, facts []Fact, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("FactsInto target must be a non-nil pointer to a struct, got %T", v)
+	}
+	bySubject := make(map[string][]Fact)
+	for _, f := range facts {
+		bySubject[f.Subject] = append(bySubject[f.Subject], f)
+	}
+	return decodeStruct(bySubject, subject, rv.Elem(), map[string]bool{})
+}
+
+func decodeStruct(bySubject map[string][]Fact, subject string, rv reflect.Value, visiting map[string]bool) error {
+	fields, subjectIndex, err := structFields(rv.Type())
+	if err != nil {
+		return err
+	}
+	if subjectIndex != nil {
+		rv.FieldByIndex(subjectIndex).SetString(subject)
+	}
+	// Stop at cycles, leaving only the subject set.
+	if visiting[subject] {
+		return nil
+	}
+	visiting[subject] = true
+	defer delete(visiting, subject)
+
+	for _, f := range fields {
+		var objects []string
+		for _, fact := range bySubject[subject] {
+			if fact.Predicate != f.predicate || (f.entity && fact.Object == "") {
+				continue
+			}
+			if object := getObjectValue(fact); object != "" {
+				objects = append(objects, object)
+			}
+		}
+		if len(objects) == 0 {
+			continue
+		}
+
+		fv := rv.FieldByIndex(f.index)
+		switch fv.Kind() {
+		case reflect.Slice:
+			out := reflect.MakeSlice(fv.Type(), len(objects), len(objects))
+			for i, object := range objects {
+				if err := decodeStructValue(bySubject, object, out.Index(i), visiting); err != nil {
+					return fmt.Errorf("field %s: %w", f.predicate, err)
+				}
+			}
+			fv.Set(out)
+		case reflect.Array:
+			for i := 0; i < fv.Len() && i < len(objects); i++ {
+				if err := decodeStructValue(bySubject, objects[i], fv.Index(i), visiting); err != nil {
+					return fmt.Errorf("field %s: %w", f.predicate, err)
+				}
+			}
+		default:
+			if err := decodeStructValue(bySubject, objects[0], fv, visiting); err != nil {
+				return fmt.Errorf("field %s: %w", f.predicate, err)
+			}
+		}
+	}
+	return nil
+}
+
+// decodeStructValue sets v from the text of one fact object.
+func decodeStructValue(bySubject map[string][]Fact, object string, v reflect.Value, visiting map[string]bool) error {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return decodeStructValue(bySubject, object, v.Elem(), visiting)
+	}
+	if isNested(v.Type()) {
+		return decodeStruct(bySubject, object, v, visiting)
+	}
+	if v.Type() == timeType {
+		for _, layout := range []string{time.RFC3339Nano, time.DateTime, time.DateOnly} {
+			if t, err := time.Parse(layout, object); err == nil {
+				v.Set(reflect.ValueOf(t))
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid time %q", object)
+	}
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(object))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(object)
+		return nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(object)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", object)
+		}
+		v.SetBool(b)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(object, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", object)
+		}
+		v.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(object, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid unsigned integer %q", object)
+		}
+		v.SetUint(n)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(object, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid number %q", object)
+		}
+		v.SetFloat(n)
+		return nil
+	}
+	return fmt.Errorf("unsupported type %s", v.Type())
+}
@@ -0,0 +1,167 @@
+package gomind
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testCompany struct {
+	Name string `gomind:",subject"`
+	City string `gomind:"located_in,entity"`
+}
+
+type testPerson struct {
+	Name     string       `gomind:",subject"`
+	Age      int          `gomind:"age,omitempty"`
+	Active   bool         `gomind:"active"`
+	Born     time.Time    `gomind:"born"`
+	Email    *string      `gomind:"email"`
+	Tags     []string     `gomind:"tag"`
+	Employer *testCompany `gomind:"works_at"`
+	Friends  []testPerson `gomind:"knows"`
+	Notes    string
+}
+
+func TestStructFacts(t *testing.T) {
+	p := testPerson{
+		Name:     "John",
+		Active:   true,
+		Born:     time.Date(1980, 1, 2, 0, 0, 0, 0, time.UTC),
+		Tags:     []string{"vip", "beta"},
+		Employer: &testCompany{Name: "Acme", City: "Berlin"},
+		Friends:  []testPerson{{Name: "Jane", Age: 37}},
+		Notes:    "not stored",
+	}
+	facts, err := StructFacts("", &p)
+	if err != nil {
+		t.Fatalf("StructFacts: %v", err)
+	}
+	want := []RememberRequest{
+		{Subject: "John", Predicate: "active", Object: "true"},
+		{Subject: "John", Predicate: "born", Object: "1980-01-02T00:00:00Z"},
+		{Subject: "John", Predicate: "tag", Object: "vip"},
+		{Subject: "John", Predicate: "tag", Object: "beta"},
+		{Subject: "John", Predicate: "works_at", Object: "Acme"},
+		{Subject: "John", Predicate: "knows", Object: "Jane"},
+		{Subject: "Acme", Predicate: "located_in", Object: "Berlin"},
+		{Subject: "Jane", Predicate: "age", Object: "37"},
+		{Subject: "Jane", Predicate: "active", Object: "false"},
+	}
+	if !reflect.DeepEqual(facts, want) {
+		t.Errorf("unexpected facts:\n got %+v\nwant %+v", facts, want)
+	}
+
+	type address struct {
+		City string `gomind:"city"`
+	}
+	type withAddress struct {
+		Home address `gomind:"lives_at"`
+	}
+	if _, err := StructFacts("John", withAddress{}); err == nil {
+		t.Error("expected a nested struct without a subject to fail")
+	}
+	type badTag struct {
+		X string `gomind:"x,sometimes"`
+	}
+	if _, err := StructFacts("John", badTag{}); err == nil {
+		t.Error("expected an unknown tag option to fail")
+	}
+}
+
+func TestFactsInto(t *testing.T) {
+	facts := []Fact{
+		{Subject: "John", Predicate: "age", Value: "42"},
+		{Subject: "John", Predicate: "active", Value: "true"},
+		{Subject: "John", Predicate: "born", Value: "1980-01-02"},
+		{Subject: "John", Predicate: "email", Value: "john@example.com"},
+		{Subject: "John", Predicate: "tag", Value: "vip"},
+		{Subject: "John", Predicate: "tag", Value: "beta"},
+		{Subject: "John", Predicate: "works_at", Object: "Acme"},
+		{Subject: "John", Predicate: "knows", Object: "Jane"},
+		{Subject: "Acme", Predicate: "located_in", Value: "somewhere"},
+		{Subject: "Acme", Predicate: "located_in", Object: "Berlin"},
+		{Subject: "Jane", Predicate: "knows", Object: "John"},
+		{Subject: "Jane", Predicate: "age", Value: "37"},
+	}
+	var p testPerson
+	if err := FactsInto("John", facts, &p); err != nil {
+		t.Fatalf("FactsInto: %v", err)
+	}
+
+	if p.Name != "John" || p.Age != 42 || !p.Active || p.Email == nil || *p.Email != "john@example.com" {
+		t.Errorf("unexpected scalars %+v", p)
+	}
+	if !p.Born.Equal(time.Date(1980, 1, 2, 0, 0, 0, 0, time.UTC)) || !reflect.DeepEqual(p.Tags, []string{"vip", "beta"}) {
+		t.Errorf("unexpected born %v or tags %v", p.Born, p.Tags)
+	}
+	// The entity option skips the literal "somewhere".
+	if p.Employer == nil || *p.Employer != (testCompany{Name: "Acme", City: "Berlin"}) {
+		t.Errorf("unexpected employer %+v", p.Employer)
+	}
+	// The cycle back to John stops with only the name set.
+	if len(p.Friends) != 1 || p.Friends[0].Age != 37 || len(p.Friends[0].Friends) != 1 ||
+		p.Friends[0].Friends[0].Name != "John" || p.Friends[0].Friends[0].Age != 0 {
+		t.Errorf("unexpected friends %+v", p.Friends)
+	}
+
+	if err := FactsInto("John", []Fact{{Subject: "John", Predicate: "age", Value: "old"}}, &p); err == nil {
+		t.Error("expected an invalid integer to fail")
+	}
+}
+
+func TestRememberStructAndRecallInto(t *testing.T) {
+	var stored RememberManyRequest
+	var recalled RecallConnectionsRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/remember_many":
+			json.NewDecoder(r.Body).Decode(&stored)
+			json.NewEncoder(w).Encode(map[string]any{"status": "ok", "result": map[string]any{}})
+		case "/v1/recall_connections":
+			json.NewDecoder(r.Body).Decode(&recalled)
+			var facts []Fact
+			for _, f := range stored.Facts {
+				facts = append(facts, Fact{Subject: f.Subject, Predicate: f.Predicate, Object: f.Object})
+			}
+			json.NewEncoder(w).Encode(map[string]any{"status": "ok", "result": RecallResponse{Facts: facts}})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewClient("test-key", WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	ctx := context.Background()
+
+	in := testPerson{Age: 42, Employer: &testCompany{Name: "Acme", City: "Berlin"}}
+	err = client.RememberStructWithOptions(ctx, "John", in, StructOptions{Collection: CollectionScope("crm"), Context: "profile"})
+	if err != nil {
+		t.Fatalf("RememberStruct: %v", err)
+	}
+	if len(stored.Facts) != 4 || *stored.Collection != "crm" || stored.Facts[0].Context != "profile" {
+		t.Errorf("unexpected stored request %+v", stored)
+	}
+
+	var out testPerson
+	if err := client.RecallInto(ctx, "John", &out); err != nil {
+		t.Fatalf("RecallInto: %v", err)
+	}
+	if recalled.Entity != "John" || recalled.Depth != 3 {
+		t.Errorf("unexpected recall request %+v", recalled)
+	}
+	if out.Name != "John" || out.Age != 42 || out.Employer.City != "Berlin" {
+		t.Errorf("unexpected round trip %+v", out)
+	}
+
+	if err := client.RecallInto(ctx, "John", out); err == nil {
+		t.Error("expected a non-pointer target to fail")
+	}
+}
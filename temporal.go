@@ -0,0 +1,89 @@
+package gomind
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// DefaultHistoryLimit is the number of facts History looks up when
+// HistoryRequest.Limit is zero.
+const DefaultHistoryLimit = 100
+
+// ValidAt reports whether f was true at t. ValidFrom is inclusive and
+// ValidTo exclusive; facts without a validity interval are always valid.
+func (f Fact) ValidAt(t time.Time) bool {
+	if f.ValidFrom != nil && t.Before(*f.ValidFrom) {
+		return false
+	}
+	if f.ValidTo != nil && !t.Before(*f.ValidTo) {
+		return false
+	}
+	return true
+}
+
+// start returns the time f became true for ordering a timeline:
+// ValidFrom, else ObservedAt, else the zero time.
+func (f Fact) start() time.Time {
+	if f.ValidFrom != nil {
+		return *f.ValidFrom
+	}
+	if f.ObservedAt != nil {
+		return *f.ObservedAt
+	}
+	return time.Time{}
+}
+
+// HistoryRequest selects the facts returned by HistoryWithOptions.
+// See RememberRequest.Collection for the *string semantics.
+type HistoryRequest struct {
+	Subject    string
+	Predicate  string
+	Limit      int
+	Collection *string
+}
+
+// History returns every stored value of subject and predicate, past and
+// present, ordered from oldest to newest.
+func (c *Client) History(ctx context.Context, subject, predicate string) ([]Fact, error) {
+	return c.HistoryWithOptions(ctx, HistoryRequest{
+		Subject:   subject,
+		Predicate: predicate,
+	})
+}
+
+// HistoryWithOptions is like History with full control over the request.
+// Facts are ordered by ValidFrom, falling back to ObservedAt; facts with
+// neither come first.
+func (c *Client) HistoryWithOptions(ctx context.Context, req HistoryRequest) ([]Fact, error) {
+	if req.Limit <= 0 {
+		req.Limit = DefaultHistoryLimit
+	}
+
+	resp, err := c.RecallWithOptions(ctx, RecallRequest{
+		Query:      req.Subject,
+		Predicate:  req.Predicate,
+		Limit:      req.Limit,
+		Collection: req.Collection,
+	})
+	if err != nil {
+		c.logger.Error("Gomind History failed", "error", err, "subject", req.Subject, "predicate", req.Predicate)
+		return nil, err
+	}
+
+	// Recall also returns facts about similar subjects. Subjects match
+	// exactly, as in Upsert: "john" is a different entity from "John".
+	facts := slices.DeleteFunc(resp.Facts, func(f Fact) bool {
+		return f.Subject != req.Subject || f.Predicate != req.Predicate
+	})
+	slices.SortStableFunc(facts, func(a, b Fact) int {
+		return a.start().Compare(b.start())
+	})
+
+	c.logger.Info("Gomind History success",
+		"subject", req.Subject,
+		"predicate", req.Predicate,
+		"factsFound", len(facts),
+	)
+	return facts, nil
+}
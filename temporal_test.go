@@ -0,0 +1,116 @@
+package gomind
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month) *time.Time {
+	t := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	return &t
+}
+
+func TestFactValidAt(t *testing.T) {
+	f := Fact{ValidFrom: date(2024, 1), ValidTo: date(2025, 1)}
+	for _, tt := range []struct {
+		at   *time.Time
+		want bool
+	}{
+		{date(2023, 12), false},
+		{date(2024, 1), true},
+		{date(2024, 6), true},
+		{date(2025, 1), false},
+	} {
+		if got := f.ValidAt(*tt.at); got != tt.want {
+			t.Errorf("ValidAt(%v) = %v, want %v", tt.at, got, tt.want)
+		}
+	}
+	if !(Fact{}).ValidAt(time.Now()) {
+		t.Error("facts without an interval must always be valid")
+	}
+}
+
+func TestRememberTemporalFields(t *testing.T) {
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"ok","result":{}}`))
+	}))
+	defer srv.Close()
+	client, err := NewClient("test-key", WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	_, err = client.RememberWithOptions(context.Background(), RememberRequest{
+		Subject: "John", Predicate: "works_at", Object: "Acme", ValidFrom: date(2024, 1),
+	})
+	if err != nil {
+		t.Fatalf("Remember: %v", err)
+	}
+	if body["valid_from"] != "2024-01-01T00:00:00Z" {
+		t.Errorf("unexpected valid_from %v", body["valid_from"])
+	}
+	if _, ok := body["valid_to"]; ok {
+		t.Error("unset valid_to must be omitted")
+	}
+}
+
+func TestHistoryAndAsOf(t *testing.T) {
+	_, client := newFactStore(t,
+		Fact{Subject: "John", Predicate: "works_at", Object: "NewCo", ValidFrom: date(2025, 1)},
+		Fact{Subject: "John", Predicate: "works_at", Object: "Acme", ValidFrom: date(2022, 3), ValidTo: date(2025, 1)},
+		Fact{Subject: "Johnny", Predicate: "works_at", Object: "Globex"},
+		Fact{Subject: "john", Predicate: "works_at", Object: "Hooli"},
+		Fact{Subject: "John", Predicate: "works_at", Object: "Initech", ObservedAt: date(2020, 5)},
+	)
+	ctx := context.Background()
+
+	history, err := client.History(ctx, "John", "works_at")
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+	var objects []string
+	for _, f := range history {
+		objects = append(objects, f.Object)
+	}
+	if got := strings.Join(objects, ","); got != "Initech,Acme,NewCo" {
+		t.Errorf("History = %s, want Initech,Acme,NewCo", got)
+	}
+
+	resp, err := client.RecallWithOptions(ctx, RecallRequest{Query: "John", Predicate: "works_at", AsOf: date(2024, 6)})
+	if err != nil {
+		t.Fatalf("Recall: %v", err)
+	}
+	for _, f := range resp.Facts {
+		if f.Object == "NewCo" {
+			t.Errorf("fact not yet valid was returned: %+v", f)
+		}
+	}
+	if resp.Count != len(resp.Facts) {
+		t.Errorf("count %d does not match %d facts", resp.Count, len(resp.Facts))
+	}
+}
+
+func TestFormatFactsAsContextTimeColumns(t *testing.T) {
+	facts := []Fact{
+		{Subject: "John", Predicate: "works_at", Object: "Acme", ValidFrom: date(2022, 3), ValidTo: date(2025, 1)},
+		{Subject: "John", Predicate: "works_at", Object: "NewCo", ValidFrom: date(2025, 1)},
+	}
+	got := FormatFactsAsContextWithOptions(facts, FactContextOptions{TimeColumns: true, TimeLayout: time.DateOnly})
+	want := "memory[2]{subject,predicate,object,valid_from,valid_to}:\n" +
+		"  John,works_at,Acme,2022-03-01,2025-01-01\n" +
+		"  John,works_at,NewCo,2025-01-01,"
+	if got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+	if got := FormatFactsAsContext(facts); strings.Contains(got, "valid_from") {
+		t.Errorf("time columns must be opt-in, got %s", got)
+	}
+}
@@ -0,0 +1,275 @@
+package gomind
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// DefaultToolConcurrency is the number of tool calls HandleToolCalls
+// runs at once when ToolBatchOptions.Concurrency is zero.
+const DefaultToolConcurrency = 4
+
+// ToolCall is a single tool invocation emitted by the model.
+type ToolCall struct {
+	ID        string
+	Name      string
+	Arguments string
+}
+
+// ToolResult is the outcome of one ToolCall. Err is set when that call
+// failed; it never affects the other calls in the batch.
+type ToolResult struct {
+	ID     string
+	Name   string
+	Result any
+	Err    error
+
+	tool      string // registered name, used for rendering
+	arguments string
+}
+
+// Text renders the result for the model; see HandleToolCallText.
+func (r ToolResult) Text(opts ToolTextOptions) string {
+	return renderToolText(r.tool, r.Name, r.arguments, r.Result, r.Err, opts)
+}
+
+// JSON renders the result as JSON, or as {"error": "..."} when the call
+// failed.
+func (r ToolResult) JSON() string {
+	if r.Err == nil {
+		out, err := marshalToolResult(r.Result)
+		if err == nil {
+			return out
+		}
+		r.Err = err
+	}
+	out, _ := json.Marshal(map[string]string{"error": r.Err.Error()})
+	return string(out)
+}
+
+// ToolBatchOptions controls HandleToolCallsWithOptions.
+type ToolBatchOptions struct {
+	// Concurrency caps the number of calls in flight. Zero uses
+	// DefaultToolConcurrency; 1 runs the batch sequentially.
+	Concurrency int
+}
+
+// writeTools are the built-in tools that modify memory. mind is included
+// because its internal agent may write.
+var writeTools = map[string]bool{
+	"remember":      true,
+	"remember_many": true,
+	"feed":          true,
+	"forget":        true,
+	"forget_entity": true,
+	"mind":          true,
+}
+
+// readTools are the built-in tools that only read memory.
+var readTools = map[string]bool{
+	"recall":             true,
+	"recall_connections": true,
+}
+
+// HandleToolCalls executes a batch of tool calls, such as the parallel
+// tool calls of one model turn, and returns one result per call in input
+// order.
+//
+// Independent calls run concurrently. When a batch both writes to and
+// reads from the same collection, the writes to that collection run
+// first, in input order, and the reads start once they are done, so a
+// recall sees the facts remembered alongside it.
+func (c *Client) HandleToolCalls(ctx context.Context, calls []ToolCall) []ToolResult {
+	return c.HandleToolCallsWithOptions(ctx, calls, ToolBatchOptions{})
+}
+
+// HandleToolCallsWithOptions is HandleToolCalls with a configurable
+// concurrency limit.
+func (c *Client) HandleToolCallsWithOptions(ctx context.Context, calls []ToolCall, opts ToolBatchOptions) []ToolResult {
+	return runToolCalls(ctx, calls, opts, c.collection, func(name string) (string, ToolHandlerFunc, error) {
+		if _, ok := builtinToolHandlers[name]; !ok {
+			return "", nil, fmt.Errorf("%w: %s", ErrUnknownTool, name)
+		}
+		return name, func(ctx context.Context, arguments string) (any, error) {
+			return c.HandleToolCall(ctx, name, arguments)
+		}, nil
+	})
+}
+
+// HandleCalls executes a batch of tool calls addressed by exported name,
+// with the ordering guarantees of Client.HandleToolCalls. Custom tools
+// are treated as independent of every other call.
+func (r *ToolRegistry) HandleCalls(ctx context.Context, calls []ToolCall, opts ToolBatchOptions) []ToolResult {
+	return runToolCalls(ctx, calls, opts, r.client.collection, func(name string) (string, ToolHandlerFunc, error) {
+		registered, handler, err := r.resolve(name)
+		if err != nil {
+			return "", nil, err
+		}
+		if !r.isBuiltin(registered) {
+			registered = ""
+		}
+		return registered, handler, nil
+	})
+}
+
+// toolResolver maps an exported tool name to the built-in tool it runs
+// ("" for custom tools) and its handler.
+type toolResolver func(name string) (builtin string, handler ToolHandlerFunc, err error)
+
+// runToolCalls schedules a batch in two phases. Phase one runs every
+// write chain (sequential within the chain), every custom tool and every
+// read of a collection nobody writes to. Writes share a chain when they
+// share a collection, so a remember_many spanning several collections
+// joins the chains of all of them. Phase two runs the reads that had to
+// wait for a write chain.
+func runToolCalls(ctx context.Context, calls []ToolCall, opts ToolBatchOptions, clientDefault string, resolve toolResolver) []ToolResult {
+	results := make([]ToolResult, len(calls))
+	handlers := make([]ToolHandlerFunc, len(calls))
+	collections := make([][]string, len(calls))
+	var chains [][]int
+	chainOf := make(map[string]int)
+
+	for i, call := range calls {
+		results[i] = ToolResult{ID: call.ID, Name: call.Name, tool: call.Name, arguments: call.Arguments}
+		builtin, handler, err := resolve(call.Name)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		if builtin != "" {
+			results[i].tool = builtin
+		}
+		handlers[i] = handler
+		collections[i] = callCollections(call.Arguments, clientDefault)
+
+		if writeTools[builtin] {
+			chain := -1
+			for _, col := range collections[i] {
+				other, ok := chainOf[col]
+				if !ok || other == chain {
+					continue
+				}
+				if chain < 0 {
+					chain = other
+					continue
+				}
+				// The call links two chains: merge them, keeping input order.
+				keep, drop := min(chain, other), max(chain, other)
+				chains[keep] = append(chains[keep], chains[drop]...)
+				slices.Sort(chains[keep])
+				chains[drop] = nil
+				for c, idx := range chainOf {
+					if idx == drop {
+						chainOf[c] = keep
+					}
+				}
+				chain = keep
+			}
+			if chain < 0 {
+				chain = len(chains)
+				chains = append(chains, nil)
+			}
+			chains[chain] = append(chains[chain], i)
+			for _, col := range collections[i] {
+				chainOf[col] = chain
+			}
+		}
+	}
+
+	var phase1, phase2 [][]int
+	for _, chain := range chains {
+		if chain != nil {
+			phase1 = append(phase1, chain)
+		}
+	}
+	for i := range calls {
+		builtin := results[i].tool
+		if handlers[i] == nil || writeTools[builtin] {
+			continue
+		}
+		written := slices.ContainsFunc(collections[i], func(col string) bool {
+			_, ok := chainOf[col]
+			return ok
+		})
+		if written && readTools[builtin] {
+			phase2 = append(phase2, []int{i})
+		} else {
+			phase1 = append(phase1, []int{i})
+		}
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultToolConcurrency
+	}
+	run := func(chain []int) {
+		for _, i := range chain {
+			results[i].Result, results[i].Err = safeInvoke(ctx, handlers[i], calls[i].Arguments)
+		}
+	}
+	runConcurrently(phase1, concurrency, run)
+	runConcurrently(phase2, concurrency, run)
+
+	return results
+}
+
+// runConcurrently runs fn over tasks with at most limit in flight.
+func runConcurrently(tasks [][]int, limit int, fn func([]int)) {
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for _, task := range tasks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(task)
+		}()
+	}
+	wg.Wait()
+}
+
+// safeInvoke runs a handler, turning a panic into an error so one bad
+// call cannot take down the batch.
+func safeInvoke(ctx context.Context, handler ToolHandlerFunc, arguments string) (result any, err error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("tool panicked: %v", p)
+		}
+	}()
+	return handler(ctx, arguments)
+}
+
+// callCollections returns the canonical collections a call targets: its
+// collection argument, else the client default, plus the collection of
+// each remember_many fact that names its own. The argument or default is
+// left out when every fact names its own collection.
+func callCollections(arguments, clientDefault string) []string {
+	var scope toolScope
+	_ = json.Unmarshal([]byte(arguments), &scope)
+
+	var cols []string
+	useCall := len(scope.Facts) == 0
+	for _, f := range scope.Facts {
+		if f.Collection != nil {
+			cols = append(cols, canonicalCollection(*f.Collection))
+		} else {
+			useCall = true
+		}
+	}
+	if useCall {
+		col := clientDefault
+		if scope.Collection != nil {
+			col = *scope.Collection
+		}
+		cols = append(cols, canonicalCollection(col))
+	}
+	slices.Sort(cols)
+	return slices.Compact(cols)
+}
@@ -0,0 +1,194 @@
+package gomind
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ingate/gomind-go-sdk/tools"
+)
+
+// TestHandleToolCallsOrdering verifies writes run before reads of the
+// same collection, reads of other collections are not held back, and
+// results keep input order.
+func TestHandleToolCallsOrdering(t *testing.T) {
+	var mu sync.Mutex
+	var events []string
+	record := func(e string) {
+		mu.Lock()
+		events = append(events, e)
+		mu.Unlock()
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Collection *string `json:"collection"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		col := ""
+		if body.Collection != nil {
+			col = *body.Collection
+		}
+		record("start " + r.URL.Path + " " + col)
+		if r.URL.Path == "/v1/remember" {
+			time.Sleep(50 * time.Millisecond)
+		}
+		record("end " + r.URL.Path + " " + col)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"OK","result":{}}`))
+	}))
+	defer srv.Close()
+
+	client, err := NewClient("test-key", WithBaseURL(srv.URL), WithCollection("kb"))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	calls := []ToolCall{
+		{ID: "1", Name: "recall", Arguments: `{"query":"John"}`},
+		{ID: "2", Name: "remember", Arguments: `{"subject":"John","predicate":"likes","object":"tea"}`},
+		{ID: "3", Name: "recall", Arguments: `{"query":"x","collection":"other"}`},
+		{ID: "4", Name: "teleport", Arguments: `{}`},
+	}
+	results := client.HandleToolCalls(context.Background(), calls)
+
+	for i, res := range results {
+		if res.ID != calls[i].ID {
+			t.Errorf("result %d: expected ID %s, got %s", i, calls[i].ID, res.ID)
+		}
+	}
+	if results[3].Err == nil {
+		t.Errorf("expected unknown tool error for call 4")
+	}
+	for _, i := range []int{0, 1, 2} {
+		if results[i].Err != nil {
+			t.Errorf("call %d: unexpected error %v", i+1, results[i].Err)
+		}
+	}
+
+	index := func(e string) int {
+		for i, got := range events {
+			if got == e {
+				return i
+			}
+		}
+		t.Fatalf("event %q not recorded: %v", e, events)
+		return -1
+	}
+	if index("start /v1/recall kb") < index("end /v1/remember kb") {
+		t.Errorf("recall in kb must wait for the remember: %v", events)
+	}
+	if index("start /v1/recall other") > index("end /v1/remember kb") {
+		t.Errorf("recall in another collection should not wait: %v", events)
+	}
+}
+
+// TestHandleToolCallsOrderingPerFactCollections verifies reads wait for a
+// remember_many that writes to their collection through a per-fact
+// collection, and writes sharing any collection run one after another.
+func TestHandleToolCallsOrderingPerFactCollections(t *testing.T) {
+	var mu sync.Mutex
+	var events []string
+	record := func(e string) {
+		mu.Lock()
+		events = append(events, e)
+		mu.Unlock()
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query string `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		name := r.URL.Path + " " + body.Query
+		record("start " + name)
+		if r.URL.Path != "/v1/recall" {
+			time.Sleep(50 * time.Millisecond)
+		}
+		record("end " + name)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"OK","result":{}}`))
+	}))
+	defer srv.Close()
+
+	client, err := NewClient("test-key", WithBaseURL(srv.URL), WithCollection("kb"))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	calls := []ToolCall{
+		{ID: "1", Name: "recall", Arguments: `{"query":"people","collection":"people"}`},
+		{ID: "2", Name: "remember_many", Arguments: `{"facts":[{"subject":"John","predicate":"likes","object":"tea"},{"subject":"Jane","predicate":"likes","object":"coffee","collection":"people"}]}`},
+		{ID: "3", Name: "recall", Arguments: `{"query":"unrelated","collection":"other"}`},
+		{ID: "4", Name: "recall", Arguments: `{"query":"kb","collection":"kb"}`},
+		{ID: "5", Name: "remember", Arguments: `{"subject":"Jane","predicate":"lives_in","object":"Oslo","collection":"people"}`},
+	}
+	for i, res := range client.HandleToolCalls(context.Background(), calls) {
+		if res.Err != nil {
+			t.Errorf("call %d: unexpected error %v", i+1, res.Err)
+		}
+	}
+
+	index := func(e string) int {
+		for i, got := range events {
+			if got == e {
+				return i
+			}
+		}
+		t.Fatalf("event %q not recorded: %v", e, events)
+		return -1
+	}
+	if index("start /v1/recall people") < index("end /v1/remember_many ") {
+		t.Errorf("recall in a per-fact collection must wait for the remember_many: %v", events)
+	}
+	if index("start /v1/recall kb") < index("end /v1/remember_many ") {
+		t.Errorf("recall in the default collection must wait for the remember_many: %v", events)
+	}
+	if index("start /v1/remember ") < index("end /v1/remember_many ") {
+		t.Errorf("writes to a shared collection must not overlap: %v", events)
+	}
+	if index("start /v1/recall people") < index("end /v1/remember ") {
+		t.Errorf("recall must wait for every write to its collection: %v", events)
+	}
+	if index("start /v1/recall unrelated") > index("end /v1/remember_many ") {
+		t.Errorf("recall in another collection should not wait: %v", events)
+	}
+}
+
+// TestRegistryHandleCallsIsolatesPanics verifies a panicking custom tool
+// only fails its own call.
+func TestRegistryHandleCallsIsolatesPanics(t *testing.T) {
+	client, err := NewClient("test-key")
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	reg := NewToolRegistry(client)
+	_ = reg.Register(tools.Definition{Name: "boom"}, func(ctx context.Context, arguments string) (any, error) {
+		panic("kaboom")
+	})
+	_ = reg.Register(tools.Definition{Name: "ok"}, func(ctx context.Context, arguments string) (any, error) {
+		return "fine", nil
+	})
+
+	results := reg.HandleCalls(context.Background(), []ToolCall{
+		{ID: "a", Name: "boom"},
+		{ID: "b", Name: "ok"},
+	}, ToolBatchOptions{Concurrency: 1})
+
+	if results[0].Err == nil {
+		t.Errorf("expected panic to surface as an error")
+	}
+	if results[1].Err != nil || results[1].Result != "fine" {
+		t.Errorf("unexpected second result: %+v", results[1])
+	}
+	if got := results[1].Text(ToolTextOptions{}); got != "fine" {
+		t.Errorf("Text() = %q", got)
+	}
+	if got := results[0].JSON(); got != `{"error":"tool panicked: kaboom"}` {
+		t.Errorf("JSON() = %q", got)
+	}
+}
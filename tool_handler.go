@@ -3,81 +3,89 @@ package gomind
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+
+	"github.com/ingate/gomind-go-sdk/tools"
 )
 
-// HandleToolCall executes a Gomind tool call and returns the result.
-// It routes the tool call to the appropriate API method based on the tool name.
-func (c *Client) HandleToolCall(ctx context.Context, name string, arguments string) (any, error) {
-	switch name {
-	case "remember":
-		var req RememberRequest
-		if err := json.Unmarshal([]byte(arguments), &req); err != nil {
-			return nil, fmt.Errorf("failed to parse remember arguments: %w", err)
-		}
-		return c.RememberWithOptions(ctx, req)
+// toolHandler executes one built-in Gomind tool against a client.
+type toolHandler func(c *Client, ctx context.Context, arguments string) (any, error)
 
-	case "remember_many":
-		var req RememberManyRequest
-		if err := json.Unmarshal([]byte(arguments), &req); err != nil {
-			return nil, fmt.Errorf("failed to parse remember_many arguments: %w", err)
-		}
-		if err := c.RememberManyWithOptions(ctx, req); err != nil {
-			return nil, err
-		}
-		return map[string]string{"status": "OK"}, nil
+// builtinToolHandlers maps every name returned by tools.Definitions to
+// its implementation. ToolRegistry dispatches built-ins through
+// HandleToolCall, so both paths share this table.
+var builtinToolHandlers = map[string]toolHandler{
+	"remember":           handleRememberTool,
+	"remember_many":      handleRememberManyTool,
+	"recall":             handleRecallTool,
+	"recall_connections": handleRecallConnectionsTool,
+	"feed":               handleFeedTool,
+	"forget":             handleForgetTool,
+	"forget_entity":      handleForgetEntityTool,
+	"mind":               handleMindTool,
+}
 
-	case "recall":
-		var req RecallRequest
-		if err := json.Unmarshal([]byte(arguments), &req); err != nil {
-			return nil, fmt.Errorf("failed to parse recall arguments: %w", err)
-		}
-		return c.RecallWithOptions(ctx, req)
+// ErrUnknownTool is returned when a tool call names a tool that does not
+// exist (or is disabled).
+var ErrUnknownTool = errors.New("unknown tool")
 
-	case "recall_connections":
-		var req RecallConnectionsRequest
-		if err := json.Unmarshal([]byte(arguments), &req); err != nil {
-			return nil, fmt.Errorf("failed to parse recall_connections arguments: %w", err)
-		}
-		return c.RecallConnectionsWithOptions(ctx, req)
+// ToolArgumentsError is returned when the arguments of a tool call are
+// not valid JSON for the tool's schema.
+type ToolArgumentsError struct {
+	Tool string
+	Err  error
+}
 
-	case "feed":
-		var req FeedRequest
-		if err := json.Unmarshal([]byte(arguments), &req); err != nil {
-			return nil, fmt.Errorf("failed to parse feed arguments: %w", err)
-		}
-		return c.FeedWithOptions(ctx, req)
+func (e *ToolArgumentsError) Error() string {
+	return fmt.Sprintf("failed to parse %s arguments: %v", e.Tool, e.Err)
+}
 
-	case "forget":
-		var req ForgetRequest
-		if err := json.Unmarshal([]byte(arguments), &req); err != nil {
-			return nil, fmt.Errorf("failed to parse forget arguments: %w", err)
-		}
-		if err := c.ForgetWithOptions(ctx, req); err != nil {
-			return nil, err
-		}
-		return map[string]string{"status": "OK"}, nil
+func (e *ToolArgumentsError) Unwrap() error { return e.Err }
 
-	case "forget_entity":
-		var req ForgetEntityRequest
-		if err := json.Unmarshal([]byte(arguments), &req); err != nil {
-			return nil, fmt.Errorf("failed to parse forget_entity arguments: %w", err)
-		}
-		if err := c.ForgetEntityWithOptions(ctx, req); err != nil {
-			return nil, err
-		}
-		return map[string]string{"status": "OK"}, nil
+// parseToolArguments decodes the JSON arguments of a call to tool.
+func parseToolArguments(tool, arguments string, v any) error {
+	if err := json.Unmarshal([]byte(arguments), v); err != nil {
+		return &ToolArgumentsError{Tool: tool, Err: err}
+	}
+	return nil
+}
 
-	case "mind":
-		var req MindRequest
-		if err := json.Unmarshal([]byte(arguments), &req); err != nil {
-			return nil, fmt.Errorf("failed to parse mind arguments: %w", err)
-		}
-		return c.MindWithOptions(ctx, req)
+// HandleToolCall executes a Gomind tool call and returns the result.
+// It routes the tool call to the appropriate API method based on the tool name.
+// Calls not permitted by the client's ToolPolicy fail with a
+// *ToolPermissionError before any request is made.
+func (c *Client) HandleToolCall(ctx context.Context, name string, arguments string) (any, error) {
+	handler, ok := builtinToolHandlers[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTool, name)
+	}
+	if err := c.toolPolicy.check(name, arguments, c.collection); err != nil {
+		c.logger.Error("Gomind tool call rejected", "error", err, "tool", name)
+		return nil, err
+	}
+	return handler(c, ctx, arguments)
+}
 
-	default:
-		return nil, fmt.Errorf("unknown tool: %s", name)
+// ToolDefinitions returns the built-in tool definitions this client
+// accepts: those permitted by its ToolPolicy, with the vocabulary of its
+// Ontology appended to the remember tools. Pass the result to
+// tools.ToOpenAI or tools.ToOpenAIResponses instead of tools.ForOpenAI
+// when either option is set.
+func (c *Client) ToolDefinitions() []tools.Definition {
+	defs := c.toolPolicy.Definitions()
+	if c.ontology != nil {
+		for i, def := range defs {
+			defs[i] = c.ontology.annotate(def)
+		}
 	}
+	return defs
+}
+
+// ToolPolicy returns the policy installed with WithToolPolicy. The zero
+// value allows everything.
+func (c *Client) ToolPolicy() ToolPolicy {
+	return c.toolPolicy
 }
 
 // HandleToolCallJSON is a convenience method that returns the tool call result as a JSON string.
@@ -87,7 +95,11 @@ func (c *Client) HandleToolCallJSON(ctx context.Context, name string, arguments
 	if err != nil {
 		return "", err
 	}
+	return marshalToolResult(result)
+}
 
+// marshalToolResult renders a tool result as a JSON string.
+func marshalToolResult(result any) (string, error) {
 	jsonBytes, err := json.Marshal(result)
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal result: %w", err)
@@ -95,3 +107,82 @@ func (c *Client) HandleToolCallJSON(ctx context.Context, name string, arguments
 
 	return string(jsonBytes), nil
 }
+
+func handleRememberTool(c *Client, ctx context.Context, arguments string) (any, error) {
+	var req RememberRequest
+	if err := parseToolArguments("remember", arguments, &req); err != nil {
+		return nil, err
+	}
+	return c.rememberWithOntology(ctx, req, c.toolPolicy.allowsForget(c.collection, req.Collection))
+}
+
+func handleRememberManyTool(c *Client, ctx context.Context, arguments string) (any, error) {
+	var req RememberManyRequest
+	if err := parseToolArguments("remember_many", arguments, &req); err != nil {
+		return nil, err
+	}
+	collections := []*string{req.Collection}
+	for _, f := range req.Facts {
+		if f.Collection != nil {
+			collections = append(collections, f.Collection)
+		}
+	}
+	if err := c.rememberManyWithOntology(ctx, req, c.toolPolicy.allowsForget(c.collection, collections...)); err != nil {
+		return nil, err
+	}
+	return map[string]string{"status": "OK"}, nil
+}
+
+func handleRecallTool(c *Client, ctx context.Context, arguments string) (any, error) {
+	var req RecallRequest
+	if err := parseToolArguments("recall", arguments, &req); err != nil {
+		return nil, err
+	}
+	return c.RecallWithOptions(ctx, req)
+}
+
+func handleRecallConnectionsTool(c *Client, ctx context.Context, arguments string) (any, error) {
+	var req RecallConnectionsRequest
+	if err := parseToolArguments("recall_connections", arguments, &req); err != nil {
+		return nil, err
+	}
+	return c.RecallConnectionsWithOptions(ctx, req)
+}
+
+func handleFeedTool(c *Client, ctx context.Context, arguments string) (any, error) {
+	var req FeedRequest
+	if err := parseToolArguments("feed", arguments, &req); err != nil {
+		return nil, err
+	}
+	return c.FeedWithOptions(ctx, req)
+}
+
+func handleForgetTool(c *Client, ctx context.Context, arguments string) (any, error) {
+	var req ForgetRequest
+	if err := parseToolArguments("forget", arguments, &req); err != nil {
+		return nil, err
+	}
+	if err := c.ForgetWithOptions(ctx, req); err != nil {
+		return nil, err
+	}
+	return map[string]string{"status": "OK"}, nil
+}
+
+func handleForgetEntityTool(c *Client, ctx context.Context, arguments string) (any, error) {
+	var req ForgetEntityRequest
+	if err := parseToolArguments("forget_entity", arguments, &req); err != nil {
+		return nil, err
+	}
+	if err := c.ForgetEntityWithOptions(ctx, req); err != nil {
+		return nil, err
+	}
+	return map[string]string{"status": "OK"}, nil
+}
+
+func handleMindTool(c *Client, ctx context.Context, arguments string) (any, error) {
+	var req MindRequest
+	if err := parseToolArguments("mind", arguments, &req); err != nil {
+		return nil, err
+	}
+	return c.MindWithOptions(ctx, req)
+}
@@ -0,0 +1,192 @@
+package gomind
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ingate/gomind-go-sdk/tools"
+)
+
+// ToolPolicy restricts which built-in tools an LLM may call through
+// HandleToolCall and which collections those calls may touch. Install it
+// with WithToolPolicy; it is enforced on every call regardless of the
+// arguments the model sends. Custom tools registered on a ToolRegistry
+// are not governed by the policy.
+//
+// The zero value allows everything.
+type ToolPolicy struct {
+	// Tools lists the built-in tools that may be called. Nil allows all
+	// of them; an empty non-nil slice allows none.
+	Tools []string
+
+	// Collections is the collection allow-list applied to every tool.
+	// Nil allows any collection. Use "" to allow the default bucket.
+	Collections []string
+
+	// ToolCollections overrides Collections for individual tools.
+	ToolCollections map[string][]string
+}
+
+// ToolPermissionError is returned when a tool call is rejected by the
+// client's ToolPolicy.
+type ToolPermissionError struct {
+	Tool       string
+	Collection string // empty when the tool itself is not permitted
+	Allowed    []string
+}
+
+func (e *ToolPermissionError) Error() string {
+	if e.Allowed == nil {
+		return fmt.Sprintf("tool %s is not permitted", e.Tool)
+	}
+	return fmt.Sprintf("tool %s is not permitted in collection %q (allowed: %s)",
+		e.Tool, e.Collection, strings.Join(quoteAll(e.Allowed), ", "))
+}
+
+// ReadOnlyPolicy allows only recall and recall_connections.
+func ReadOnlyPolicy() ToolPolicy {
+	return ToolPolicy{Tools: slices.Clone(tools.ReadOnlyTools)}
+}
+
+// AppendOnlyPolicy allows every tool that cannot remove facts.
+func AppendOnlyPolicy() ToolPolicy {
+	return ToolPolicy{Tools: slices.Clone(tools.AppendOnlyTools)}
+}
+
+// FullPolicy allows every built-in tool. It is equivalent to the zero
+// value and exists for readability at call sites.
+func FullPolicy() ToolPolicy {
+	return ToolPolicy{}
+}
+
+// WithCollections returns a copy of p restricted to the given
+// collections for every tool.
+func (p ToolPolicy) WithCollections(codes ...string) ToolPolicy {
+	p.Collections = codes
+	return p
+}
+
+// Allows reports whether the named built-in tool may be called.
+func (p ToolPolicy) Allows(name string) bool {
+	return p.Tools == nil || slices.Contains(p.Tools, name)
+}
+
+// AllowsCollection reports whether the Collections allow-list permits
+// the collection with the given code. ToolCollections overrides are not
+// consulted.
+func (p ToolPolicy) AllowsCollection(code string) bool {
+	return p.Collections == nil || collectionAllowed(code, p.Collections)
+}
+
+// Definitions returns the built-in tool definitions permitted by p, so
+// the model is only offered tools it may call.
+func (p ToolPolicy) Definitions() []tools.Definition {
+	if p.Tools == nil {
+		return tools.Definitions()
+	}
+	return tools.Select(p.Tools...)
+}
+
+// toolScope captures the collection arguments of any built-in tool.
+type toolScope struct {
+	Collection *string `json:"collection"`
+	Facts      []struct {
+		Collection *string `json:"collection"`
+	} `json:"facts"`
+}
+
+// check validates a tool call against the policy. clientDefault is the
+// collection the client would inject when the model omits one.
+func (p ToolPolicy) check(name, arguments, clientDefault string) error {
+	if !p.Allows(name) {
+		return &ToolPermissionError{Tool: name}
+	}
+
+	allowed, ok := p.ToolCollections[name]
+	if !ok {
+		allowed = p.Collections
+	}
+	if allowed == nil {
+		return nil
+	}
+
+	var scope toolScope
+	if err := parseToolArguments(name, arguments, &scope); err != nil {
+		return err
+	}
+
+	requested := []*string{scope.Collection}
+	for _, f := range scope.Facts {
+		if f.Collection != nil {
+			requested = append(requested, f.Collection)
+		}
+	}
+
+	for _, col := range requested {
+		effective := clientDefault
+		if col != nil {
+			effective = *col
+		}
+		if !collectionAllowed(effective, allowed) {
+			return &ToolPermissionError{Tool: name, Collection: effective, Allowed: allowed}
+		}
+	}
+	return nil
+}
+
+// allowsForget reports whether forget may be called in every one of
+// collections (nil meaning clientDefault). The remember tools only
+// replace stored values under Ontology.UpsertSingle when it does.
+func (p ToolPolicy) allowsForget(clientDefault string, collections ...*string) bool {
+	if !p.Allows("forget") {
+		return false
+	}
+	allowed, ok := p.ToolCollections["forget"]
+	if !ok {
+		allowed = p.Collections
+	}
+	if allowed == nil {
+		return true
+	}
+	for _, col := range collections {
+		effective := clientDefault
+		if col != nil {
+			effective = *col
+		}
+		if !collectionAllowed(effective, allowed) {
+			return false
+		}
+	}
+	return true
+}
+
+// collectionAllowed compares codes after mapping the empty string and
+// reserved aliases to the default bucket, as the server does.
+func collectionAllowed(code string, allowed []string) bool {
+	code = canonicalCollection(code)
+	for _, a := range allowed {
+		if canonicalCollection(a) == code {
+			return true
+		}
+	}
+	return false
+}
+
+// canonicalCollection maps reserved default-bucket aliases to "".
+func canonicalCollection(code string) string {
+	code = strings.TrimSpace(code)
+	switch strings.ToLower(code) {
+	case "default", "none", "null", "nil", "undefined":
+		return ""
+	}
+	return code
+}
+
+func quoteAll(values []string) []string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return quoted
+}
@@ -0,0 +1,93 @@
+package gomind
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// TestToolPolicyEnforcement verifies that rejected calls never reach the
+// API, whatever collection the model puts in the arguments.
+func TestToolPolicyEnforcement(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"OK","result":{}}`))
+	}))
+	defer srv.Close()
+
+	policy := AppendOnlyPolicy().WithCollections("support")
+	policy.ToolCollections = map[string][]string{"recall": {"support", "faq"}}
+
+	client, err := NewClient("test-key",
+		WithBaseURL(srv.URL),
+		WithCollection("support"),
+		WithToolPolicy(policy),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		tool      string
+		args      string
+		wantAllow bool
+	}{
+		{"client default", "remember", `{"subject":"s","predicate":"p","object":"o"}`, true},
+		{"explicit allowed", "remember", `{"subject":"s","predicate":"p","object":"o","collection":"support"}`, true},
+		{"escape to other collection", "remember", `{"subject":"s","predicate":"p","object":"o","collection":"prod"}`, false},
+		{"escape to default bucket", "remember", `{"subject":"s","predicate":"p","object":"o","collection":"default"}`, false},
+		{"per-fact escape", "remember_many", `{"facts":[{"subject":"s","predicate":"p","object":"o","collection":"prod"}]}`, false},
+		{"per-tool allow-list", "recall", `{"query":"x","collection":"faq"}`, true},
+		{"forget not permitted", "forget", `{"subject":"s","predicate":"p","object":"o"}`, false},
+		{"forget_entity not permitted", "forget_entity", `{"entity":"e"}`, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			before := hits.Load()
+			_, err := client.HandleToolCall(context.Background(), tc.tool, tc.args)
+			var permErr *ToolPermissionError
+			if tc.wantAllow {
+				if err != nil {
+					t.Fatalf("expected call to be allowed, got %v", err)
+				}
+				if hits.Load() == before {
+					t.Errorf("expected request to reach the server")
+				}
+				return
+			}
+			if !errors.As(err, &permErr) {
+				t.Fatalf("expected *ToolPermissionError, got %v", err)
+			}
+			if hits.Load() != before {
+				t.Errorf("rejected call must not reach the server")
+			}
+		})
+	}
+}
+
+// TestToolPolicyDefinitions verifies presets only export permitted
+// tools, directly and through a registry.
+func TestToolPolicyDefinitions(t *testing.T) {
+	defs := ReadOnlyPolicy().Definitions()
+	if len(defs) != 2 || defs[0].Name != "recall" || defs[1].Name != "recall_connections" {
+		t.Errorf("unexpected read-only definitions: %+v", defs)
+	}
+
+	client, err := NewClient("test-key", WithToolPolicy(ReadOnlyPolicy()))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if got := len(NewToolRegistry(client).Definitions()); got != 2 {
+		t.Errorf("expected registry to export 2 tools, got %d", got)
+	}
+	if got := len(FullPolicy().Definitions()); got != 8 {
+		t.Errorf("expected full policy to export 8 tools, got %d", got)
+	}
+}
@@ -0,0 +1,300 @@
+package gomind
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ingate/gomind-go-sdk/tools"
+)
+
+// ToolHandlerFunc executes a tool call with the raw JSON arguments sent
+// by the model and returns a JSON-serializable result.
+type ToolHandlerFunc func(ctx context.Context, arguments string) (any, error)
+
+// ToolMiddleware wraps a tool handler to run code before and/or after
+// it. name is the tool's registered name, independent of any rename or
+// prefix applied for export.
+type ToolMiddleware func(name string, next ToolHandlerFunc) ToolHandlerFunc
+
+// registeredTool is a single ToolRegistry entry.
+type registeredTool struct {
+	def        tools.Definition
+	handler    ToolHandlerFunc
+	exposed    string // name shown to the model, before the registry prefix
+	builtin    bool
+	disabled   bool
+	middleware []ToolMiddleware
+}
+
+// ToolRegistry is the set of tools exposed to an LLM. It drives both
+// definition export (Definitions) and dispatch (Handle), so the schema
+// the model sees always matches what the handler accepts.
+//
+// A registry starts with Gomind's built-in tools bound to a client.
+// Custom tools can be registered alongside them, and any tool can be
+// disabled, renamed, re-described or wrapped with middleware. Tools are
+// addressed by their registered name in every method except Handle,
+// which takes the exported name the model called.
+type ToolRegistry struct {
+	mu         sync.RWMutex
+	client     *Client
+	prefix     string
+	order      []string
+	tools      map[string]*registeredTool
+	middleware []ToolMiddleware
+}
+
+// NewToolRegistry returns a registry preloaded with every built-in
+// Gomind tool, dispatching to c through HandleToolCall. Built-in tools
+// not permitted by c's ToolPolicy are never exported.
+func NewToolRegistry(c *Client) *ToolRegistry {
+	r := &ToolRegistry{
+		client: c,
+		tools:  make(map[string]*registeredTool),
+	}
+	for _, def := range tools.Definitions() {
+		name := def.Name
+		r.add(def, func(ctx context.Context, arguments string) (any, error) {
+			return c.HandleToolCall(ctx, name, arguments)
+		})
+		r.tools[name].builtin = true
+	}
+	return r
+}
+
+// Register adds a custom tool. The name must not collide with the
+// registered or exported name of any existing tool.
+func (r *ToolRegistry) Register(def tools.Definition, handler ToolHandlerFunc) error {
+	if strings.TrimSpace(def.Name) == "" {
+		return fmt.Errorf("tool name is required")
+	}
+	if handler == nil {
+		return fmt.Errorf("tool %s: handler is required", def.Name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.nameTaken(def.Name) {
+		return fmt.Errorf("tool %s is already registered", def.Name)
+	}
+	r.add(def, handler)
+	return nil
+}
+
+// Disable hides the named tools from Definitions and rejects calls to
+// them in Handle.
+func (r *ToolRegistry) Disable(names ...string) error {
+	return r.setDisabled(names, true)
+}
+
+// Enable re-exposes tools previously hidden with Disable.
+func (r *ToolRegistry) Enable(names ...string) error {
+	return r.setDisabled(names, false)
+}
+
+// Rename changes the name a tool is exported under. The registered name
+// keeps working for every other registry method.
+func (r *ToolRegistry) Rename(name, exposed string) error {
+	if strings.TrimSpace(exposed) == "" {
+		return fmt.Errorf("exported tool name is required")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, err := r.lookup(name)
+	if err != nil {
+		return err
+	}
+	if exposed != t.exposed && r.nameTaken(exposed) {
+		return fmt.Errorf("tool %s is already registered", exposed)
+	}
+	t.exposed = exposed
+	return nil
+}
+
+// SetPrefix prepends prefix to every exported tool name, e.g. "memory_"
+// turns "recall" into "memory_recall". Pass "" to remove it.
+func (r *ToolRegistry) SetPrefix(prefix string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.prefix = prefix
+}
+
+// SetDescription overrides the description exported for a tool.
+func (r *ToolRegistry) SetDescription(name, description string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, err := r.lookup(name)
+	if err != nil {
+		return err
+	}
+	t.def.Description = description
+	return nil
+}
+
+// Wrap adds middleware to a single tool. Middleware added first runs
+// outermost.
+func (r *ToolRegistry) Wrap(name string, mw ...ToolMiddleware) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, err := r.lookup(name)
+	if err != nil {
+		return err
+	}
+	t.middleware = append(t.middleware, mw...)
+	return nil
+}
+
+// Use adds middleware to every tool, including tools registered later.
+// Registry-wide middleware runs outside per-tool middleware.
+func (r *ToolRegistry) Use(mw ...ToolMiddleware) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.middleware = append(r.middleware, mw...)
+}
+
+// Definitions returns the enabled tools in registration order, using
+// their exported names and descriptions. Pass the result to
+// tools.ToOpenAI or tools.ToOpenAIResponses.
+func (r *ToolRegistry) Definitions() []tools.Definition {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	defs := make([]tools.Definition, 0, len(r.order))
+	for _, name := range r.order {
+		t := r.tools[name]
+		if t.disabled || (t.builtin && !r.client.toolPolicy.Allows(name)) {
+			continue
+		}
+		def := t.def
+		if o := r.client.ontology; o != nil && t.builtin {
+			def = o.annotate(def)
+		}
+		def.Name = r.prefix + t.exposed
+		defs = append(defs, def)
+	}
+	return defs
+}
+
+// Handle executes a tool call addressed by its exported name.
+func (r *ToolRegistry) Handle(ctx context.Context, name string, arguments string) (any, error) {
+	_, handler, err := r.resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, arguments)
+}
+
+// HandleJSON is like Handle but returns the result as a JSON string.
+func (r *ToolRegistry) HandleJSON(ctx context.Context, name string, arguments string) (string, error) {
+	result, err := r.Handle(ctx, name, arguments)
+	if err != nil {
+		return "", err
+	}
+	return marshalToolResult(result)
+}
+
+// HandleText is like Handle but renders the outcome as text for the
+// model; see Client.HandleToolCallText.
+func (r *ToolRegistry) HandleText(ctx context.Context, name string, arguments string, opts ToolTextOptions) string {
+	registered, handler, err := r.resolve(name)
+	if err != nil {
+		return renderToolText(name, name, arguments, nil, err, opts)
+	}
+	result, err := handler(ctx, arguments)
+	return renderToolText(registered, name, arguments, result, err, opts)
+}
+
+// resolve maps an exported name to the tool's registered name and its
+// handler wrapped in middleware.
+func (r *ToolRegistry) resolve(name string) (string, ToolHandlerFunc, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	t := r.byExportedName(name)
+	if t == nil {
+		return "", nil, fmt.Errorf("%w: %s", ErrUnknownTool, name)
+	}
+	handler := t.handler
+	for i := len(t.middleware) - 1; i >= 0; i-- {
+		handler = t.middleware[i](t.def.Name, handler)
+	}
+	for i := len(r.middleware) - 1; i >= 0; i-- {
+		handler = r.middleware[i](t.def.Name, handler)
+	}
+	return t.def.Name, handler, nil
+}
+
+// add appends a tool without validation. Callers must hold mu or own r.
+func (r *ToolRegistry) add(def tools.Definition, handler ToolHandlerFunc) {
+	r.tools[def.Name] = &registeredTool{
+		def:     def,
+		handler: handler,
+		exposed: def.Name,
+	}
+	r.order = append(r.order, def.Name)
+}
+
+func (r *ToolRegistry) setDisabled(names []string, disabled bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, name := range names {
+		t, err := r.lookup(name)
+		if err != nil {
+			return err
+		}
+		t.disabled = disabled
+	}
+	return nil
+}
+
+// isBuiltin reports whether the registered name is a Gomind built-in.
+func (r *ToolRegistry) isBuiltin(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.tools[name]
+	return ok && t.builtin
+}
+
+func (r *ToolRegistry) lookup(name string) (*registeredTool, error) {
+	t, ok := r.tools[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTool, name)
+	}
+	return t, nil
+}
+
+// nameTaken reports whether name is in use as a registered or exported
+// (unprefixed) name.
+func (r *ToolRegistry) nameTaken(name string) bool {
+	if _, ok := r.tools[name]; ok {
+		return true
+	}
+	for _, t := range r.tools {
+		if t.exposed == name {
+			return true
+		}
+	}
+	return false
+}
+
+// byExportedName finds an enabled tool by the name the model called.
+func (r *ToolRegistry) byExportedName(name string) *registeredTool {
+	exposed, ok := strings.CutPrefix(name, r.prefix)
+	if !ok {
+		return nil
+	}
+	for _, t := range r.tools {
+		if t.exposed == exposed && !t.disabled {
+			return t
+		}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package gomind
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ingate/gomind-go-sdk/tools"
+)
+
+// TestToolRegistryDefaultsMatchDefinitions verifies a fresh registry
+// exports exactly tools.Definitions(), and that every built-in has a
+// handler so export and dispatch cannot drift.
+func TestToolRegistryDefaultsMatchDefinitions(t *testing.T) {
+	client, err := NewClient("test-key")
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	want := tools.Definitions()
+	got := NewToolRegistry(client).Definitions()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d definitions, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i].Name != want[i].Name {
+			t.Errorf("definition %d: expected %q, got %q", i, want[i].Name, got[i].Name)
+		}
+		if _, ok := builtinToolHandlers[want[i].Name]; !ok {
+			t.Errorf("built-in %q has no handler", want[i].Name)
+		}
+	}
+}
+
+// TestToolRegistryCustomizeAndDispatch covers disable, rename, prefix,
+// description override, custom tools and middleware ordering.
+func TestToolRegistryCustomizeAndDispatch(t *testing.T) {
+	var capturedPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedPath = r.URL.Path
+		_, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"OK","result":{"facts":[],"count":0}}`))
+	}))
+	defer srv.Close()
+
+	client, err := NewClient("test-key", WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	reg := NewToolRegistry(client)
+	if err := reg.Disable("forget_entity"); err != nil {
+		t.Fatalf("Disable: %v", err)
+	}
+	if err := reg.Rename("recall", "search"); err != nil {
+		t.Fatalf("Rename: %v", err)
+	}
+	if err := reg.SetDescription("search", "ignored"); err == nil {
+		t.Errorf("expected SetDescription by exported name to fail")
+	}
+	if err := reg.SetDescription("recall", "Look things up"); err != nil {
+		t.Fatalf("SetDescription: %v", err)
+	}
+	if err := reg.Register(tools.Definition{Name: "echo", Description: "Echo"}, func(ctx context.Context, arguments string) (any, error) {
+		return arguments, nil
+	}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := reg.Register(tools.Definition{Name: "search"}, func(ctx context.Context, arguments string) (any, error) {
+		return nil, nil
+	}); err == nil {
+		t.Errorf("expected duplicate exported name to be rejected")
+	}
+	reg.SetPrefix("memory_")
+
+	var trace []string
+	reg.Use(func(name string, next ToolHandlerFunc) ToolHandlerFunc {
+		return func(ctx context.Context, arguments string) (any, error) {
+			trace = append(trace, "outer:"+name)
+			return next(ctx, arguments)
+		}
+	})
+	if err := reg.Wrap("recall", func(name string, next ToolHandlerFunc) ToolHandlerFunc {
+		return func(ctx context.Context, arguments string) (any, error) {
+			trace = append(trace, "inner:"+name)
+			return next(ctx, arguments)
+		}
+	}); err != nil {
+		t.Fatalf("Wrap: %v", err)
+	}
+
+	names := make(map[string]tools.Definition)
+	for _, def := range reg.Definitions() {
+		names[def.Name] = def
+	}
+	if _, ok := names["memory_forget_entity"]; ok {
+		t.Errorf("disabled tool should not be exported")
+	}
+	if def, ok := names["memory_search"]; !ok || def.Description != "Look things up" {
+		t.Errorf("expected renamed recall with overridden description, got %+v", names)
+	}
+	if _, ok := names["memory_echo"]; !ok {
+		t.Errorf("expected custom tool to be exported with prefix")
+	}
+
+	ctx := context.Background()
+	if _, err := reg.Handle(ctx, "memory_search", `{"query":"x"}`); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if capturedPath != "/v1/recall" {
+		t.Errorf("expected dispatch to /v1/recall, got %q", capturedPath)
+	}
+	if strings.Join(trace, ",") != "outer:recall,inner:recall" {
+		t.Errorf("unexpected middleware order %v", trace)
+	}
+
+	out, err := reg.HandleJSON(ctx, "memory_echo", `hi`)
+	if err != nil || out != `"hi"` {
+		t.Errorf("HandleJSON(echo) = %q, %v", out, err)
+	}
+
+	for _, name := range []string{"recall", "memory_recall", "memory_forget_entity"} {
+		if _, err := reg.Handle(ctx, name, `{}`); !errors.Is(err, ErrUnknownTool) {
+			t.Errorf("expected %q to be rejected with ErrUnknownTool, got %v", name, err)
+		}
+	}
+
+	// Error text names the tool the way the model called it.
+	if got := reg.HandleText(ctx, "memory_search", `{"query":`, ToolTextOptions{}); !strings.HasPrefix(got, "Error: invalid arguments for memory_search:") {
+		t.Errorf("unexpected error text %q", got)
+	}
+}
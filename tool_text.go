@@ -0,0 +1,212 @@
+package gomind
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultToolTextMaxChars is the result size cap used by
+// HandleToolCallText when ToolTextOptions.MaxChars is zero.
+const DefaultToolTextMaxChars = 8000
+
+// ToolTextOptions controls how tool results are rendered as text.
+type ToolTextOptions struct {
+	// MaxChars caps the length of the rendered result. Zero uses
+	// DefaultToolTextMaxChars; a negative value disables the cap.
+	// Recall results are cut at a fact boundary and end with a notice
+	// telling the model how many facts were omitted.
+	MaxChars int
+}
+
+// HandleToolCallText executes a tool call and renders the outcome as
+// compact text for the model: recalled facts in TOON (see
+// FormatFactsAsContext), writes as one-line confirmations, and errors as
+// short actionable messages. It never returns an error; failures are
+// part of the text so they can be fed straight back to the model.
+func (c *Client) HandleToolCallText(ctx context.Context, name string, arguments string) string {
+	return c.HandleToolCallTextWithOptions(ctx, name, arguments, ToolTextOptions{})
+}
+
+// HandleToolCallTextWithOptions is HandleToolCallText with control over
+// the result size cap.
+func (c *Client) HandleToolCallTextWithOptions(ctx context.Context, name string, arguments string, opts ToolTextOptions) string {
+	result, err := c.HandleToolCall(ctx, name, arguments)
+	return renderToolText(name, name, arguments, result, err, opts)
+}
+
+// renderToolText renders a tool outcome. name must be the built-in (or
+// registered) tool name and selects the rendering; exposed is the name
+// the model called and is the one error messages refer to.
+func renderToolText(name, exposed, arguments string, result any, err error, opts ToolTextOptions) string {
+	maxChars := opts.MaxChars
+	if maxChars == 0 {
+		maxChars = DefaultToolTextMaxChars
+	}
+
+	if err != nil {
+		return truncateText(toolErrorText(exposed, err), maxChars)
+	}
+
+	switch r := result.(type) {
+	case *RecallResponse:
+		return recallText(r, maxChars)
+	case *RememberResponse:
+		object := r.Object
+		if object == "" {
+			object = r.Value
+		}
+		return truncateText(fmt.Sprintf("Remembered: %s %s %s.", r.Subject, r.Predicate, object), maxChars)
+	case *FeedResponse:
+		return truncateText(feedText(r), maxChars)
+	case *MindResponse:
+		return truncateText(Encode(r.Result), maxChars)
+	case string:
+		return truncateText(r, maxChars)
+	}
+
+	switch name {
+	case "remember_many":
+		var req RememberManyRequest
+		_ = json.Unmarshal([]byte(arguments), &req)
+		return fmt.Sprintf("Remembered %d facts.", len(req.Facts))
+	case "forget":
+		var req ForgetRequest
+		_ = json.Unmarshal([]byte(arguments), &req)
+		return truncateText(fmt.Sprintf("Forgot: %s %s %s.", req.Subject, req.Predicate, req.Object), maxChars)
+	case "forget_entity":
+		var req ForgetEntityRequest
+		_ = json.Unmarshal([]byte(arguments), &req)
+		return truncateText(fmt.Sprintf("Forgot all facts about %s.", req.Entity), maxChars)
+	}
+
+	return truncateText(Encode(result), maxChars)
+}
+
+// recallText renders recalled facts, dropping trailing facts until the
+// output fits within maxChars.
+func recallText(r *RecallResponse, maxChars int) string {
+	if len(r.Facts) == 0 {
+		msg := "No matching facts found."
+		if len(r.Suggestions) > 0 {
+			msg += " Did you mean: " + strings.Join(r.Suggestions, ", ") + "?"
+		}
+		return truncateText(msg, maxChars)
+	}
+
+	text := FormatFactsAsContext(r.Facts)
+	if maxChars < 0 || len(text) <= maxChars {
+		return text
+	}
+
+	render := func(n int) string {
+		return FormatFactsAsContext(r.Facts[:n]) + fmt.Sprintf(
+			"\n[truncated: showing %d of %d facts; narrow the query or lower the limit to see the rest]", n, len(r.Facts))
+	}
+
+	// Binary search for the largest prefix that fits.
+	lo, hi := 0, len(r.Facts)-1
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if len(render(mid)) <= maxChars {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	if lo == 0 {
+		return truncateText(text, maxChars)
+	}
+	return render(lo)
+}
+
+func feedText(r *FeedResponse) string {
+	if r.JobID != "" {
+		return fmt.Sprintf("Feed accepted as job %s; facts will be extracted in the background.", r.JobID)
+	}
+	msg := fmt.Sprintf("Extracted %d facts (%d new facts, %d new entities).", r.FactsExtracted, r.FactsCreated, r.EntitiesCreated)
+	if ctx := FormatFactsAsContext(r.Facts); ctx != "" {
+		msg += "\n" + ctx
+	}
+	return msg
+}
+
+// toolErrorText turns an error into a short message telling the model
+// what went wrong and what to do next. name is the tool name as the
+// model called it.
+func toolErrorText(name string, err error) string {
+	var permErr *ToolPermissionError
+	var apiErr *APIError
+	var ontErr *OntologyError
+	var argErr *ToolArgumentsError
+	switch {
+	case errors.As(err, &ontErr):
+		return fmt.Sprintf("Error: %s. %s.", ontErr.Error(), ontErr.hint())
+	case errors.As(err, &permErr):
+		if permErr.Allowed == nil {
+			return fmt.Sprintf("Error: tool %s is not available to you. Do not call it again.", name)
+		}
+		return fmt.Sprintf("Error: tool %s is not permitted in collection %q (allowed: %s). Retry with an allowed collection.",
+			name, permErr.Collection, strings.Join(quoteAll(permErr.Allowed), ", "))
+	case errors.Is(err, context.DeadlineExceeded):
+		return fmt.Sprintf("Error: %s timed out. Retry with a narrower request.", name)
+	case errors.Is(err, context.Canceled):
+		return fmt.Sprintf("Error: %s was cancelled.", name)
+	case errors.As(err, &apiErr):
+		detail := apiErrorDetail(apiErr)
+		if apiErr.StatusCode >= 500 {
+			return fmt.Sprintf("Error: memory service failed (status %d): %s. Retry later.", apiErr.StatusCode, detail)
+		}
+		return fmt.Sprintf("Error: %s was rejected (status %d): %s. Fix the arguments and retry.", name, apiErr.StatusCode, detail)
+	case errors.Is(err, ErrUnknownTool):
+		return fmt.Sprintf("Error: unknown tool %s. Use one of the tools provided.", name)
+	case errors.As(err, &argErr):
+		return fmt.Sprintf("Error: invalid arguments for %s: %s. Check the tool schema and retry.", name, argErr.Err)
+	}
+	return fmt.Sprintf("Error: %s failed: %s.", name, err.Error())
+}
+
+// apiErrorDetail extracts the server's error message from an API error
+// body, falling back to the (shortened) raw body.
+func apiErrorDetail(e *APIError) string {
+	var body struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal([]byte(e.Body), &body) == nil {
+		if body.Message != "" {
+			return body.Message
+		}
+		if body.Error != "" {
+			return body.Error
+		}
+	}
+	return truncateText(strings.TrimSpace(e.Body), 200)
+}
+
+// truncateText cuts s to maxChars, appending a notice. A negative
+// maxChars disables truncation. When maxChars is too small for the
+// notice, s is cut without one.
+func truncateText(s string, maxChars int) string {
+	if maxChars < 0 || len(s) <= maxChars {
+		return s
+	}
+	notice := func(omitted int) string { return fmt.Sprintf(" [truncated %d chars]", omitted) }
+	// No more than len(s) chars are omitted, so this reserves enough
+	// room for the notice.
+	cut := maxChars - len(notice(len(s)))
+	if cut <= 0 {
+		cut = maxChars
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		return s[:cut]
+	}
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + notice(len(s)-cut)
+}
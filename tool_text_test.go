@@ -0,0 +1,92 @@
+package gomind
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestHandleToolCallText covers the main rendering paths: TOON recall
+// output, write confirmations and actionable error messages.
+func TestHandleToolCallText(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/recall":
+			_, _ = w.Write([]byte(`{"status":"OK","result":{"facts":[{"subject":"John","predicate":"works_at","object":"Acme"}],"count":1}}`))
+		case "/v1/remember":
+			_, _ = w.Write([]byte(`{"status":"OK","result":{"subject":"John","predicate":"likes","value":"tea"}}`))
+		case "/v1/recall_connections":
+			_, _ = w.Write([]byte(`not json`))
+		case "/v1/forget_entity":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"entity not found"}`))
+		default:
+			_, _ = w.Write([]byte(`{"status":"OK","result":{}}`))
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewClient("test-key", WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		tool string
+		args string
+		want string
+	}{
+		{"recall as toon", "recall", `{"query":"John"}`, "memory[1]{subject,predicate,object}:\n  John,works_at,Acme"},
+		{"remember confirmation", "remember", `{"subject":"John","predicate":"likes","object":"tea"}`, "Remembered: John likes tea."},
+		{"remember_many confirmation", "remember_many", `{"facts":[{"subject":"a","predicate":"b","object":"c"},{"subject":"d","predicate":"e","object":"f"}]}`, "Remembered 2 facts."},
+		{"forget confirmation", "forget", `{"subject":"a","predicate":"b","object":"c"}`, "Forgot: a b c."},
+		{"api error", "forget_entity", `{"entity":"Ghost"}`, "Error: forget_entity was rejected (status 404): entity not found. Fix the arguments and retry."},
+		{"bad arguments", "recall", `{"query":`, "Error: invalid arguments for recall"},
+		{"unknown tool", "teleport", `{}`, "Error: unknown tool teleport."},
+		// A malformed server response is not the model's fault.
+		{"bad response", "recall_connections", `{"entity":"John"}`, "Error: recall_connections failed: failed to parse recall_connections response"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := client.HandleToolCallText(ctx, tc.tool, tc.args)
+			if !strings.HasPrefix(got, tc.want) {
+				t.Errorf("got %q, want prefix %q", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestRecallTextTruncation verifies large recalls are cut at a fact
+// boundary with a notice, and stay within the cap.
+func TestRecallTextTruncation(t *testing.T) {
+	facts := make([]Fact, 200)
+	for i := range facts {
+		facts[i] = Fact{Subject: fmt.Sprintf("entity-%03d", i), Predicate: "related_to", Object: "something"}
+	}
+
+	got := recallText(&RecallResponse{Facts: facts, Count: len(facts)}, 500)
+	if len(got) > 500 {
+		t.Errorf("expected at most 500 chars, got %d", len(got))
+	}
+	if !strings.Contains(got, "of 200 facts") {
+		t.Errorf("expected truncation notice, got %q", got)
+	}
+	if strings.Contains(got, "entity-199") {
+		t.Errorf("expected trailing facts to be dropped")
+	}
+
+	if got := truncateText(strings.Repeat("x", 100), 40); len(got) > 40 || !strings.Contains(got, "[truncated") {
+		t.Errorf("unexpected truncateText output %q", got)
+	}
+	// Limits smaller than the notice cut without one, on a rune boundary.
+	if got := truncateText(strings.Repeat("é", 50), 9); got != "éééé" {
+		t.Errorf("unexpected truncateText output %q", got)
+	}
+}
@@ -29,7 +29,9 @@ type Definition struct {
 	Parameters  []*Param
 }
 
-// Definitions returns all Gomind tool definitions.
+// Definitions returns all Gomind tool definitions. They do not reflect a
+// client's tool policy or ontology; use Client.ToolDefinitions or a
+// ToolRegistry for that.
 func Definitions() []Definition {
 	return []Definition{
 		rememberDef(),
@@ -8,8 +8,15 @@ import (
 )
 
 // ForOpenAI returns all Gomind tools in OpenAI Chat Completions API format.
+// Like Definitions, it ignores client settings; convert
+// Client.ToolDefinitions to show the model an ontology's vocabulary.
 func ForOpenAI() []openai.ChatCompletionToolUnionParam {
-	defs := Definitions()
+	return ToOpenAI(Definitions())
+}
+
+// ToOpenAI converts the given definitions to OpenAI Chat Completions API
+// format. Use it with a filtered or customized set of definitions.
+func ToOpenAI(defs []Definition) []openai.ChatCompletionToolUnionParam {
 	tools := make([]openai.ChatCompletionToolUnionParam, len(defs))
 	for i, def := range defs {
 		tools[i] = defToOpenAI(def)
@@ -18,8 +25,15 @@ func ForOpenAI() []openai.ChatCompletionToolUnionParam {
 }
 
 // ForOpenAIResponses returns all Gomind tools in OpenAI Responses API format.
+// Like Definitions, it ignores client settings; convert
+// Client.ToolDefinitions to show the model an ontology's vocabulary.
 func ForOpenAIResponses() []responses.ToolUnionParam {
-	defs := Definitions()
+	return ToOpenAIResponses(Definitions())
+}
+
+// ToOpenAIResponses converts the given definitions to OpenAI Responses
+// API format.
+func ToOpenAIResponses(defs []Definition) []responses.ToolUnionParam {
 	tools := make([]responses.ToolUnionParam, len(defs))
 	for i, def := range defs {
 		tools[i] = defToOpenAIResponses(def)
@@ -0,0 +1,26 @@
+package tools
+
+// ReadOnlyTools names the tools that only read from memory.
+var ReadOnlyTools = []string{"recall", "recall_connections"}
+
+// AppendOnlyTools names the tools that read from or add to memory but
+// never remove anything. mind is excluded because its internal agent can
+// forget facts on the model's behalf.
+var AppendOnlyTools = []string{"remember", "remember_many", "recall", "recall_connections", "feed"}
+
+// Select returns the definitions for the named tools, in Definitions
+// order. Unknown names are ignored.
+func Select(names ...string) []Definition {
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	var defs []Definition
+	for _, def := range Definitions() {
+		if wanted[def.Name] {
+			defs = append(defs, def)
+		}
+	}
+	return defs
+}
@@ -0,0 +1,53 @@
+package tools
+
+import "sort"
+
+// JSONSchema returns params as a standard JSON Schema object, for
+// providers and protocols (such as MCP) that take plain JSON Schema.
+// Unlike the OpenAI strict-mode converters, only params marked Required
+// are listed as required.
+func JSONSchema(params []*Param) map[string]any {
+	properties := make(map[string]any, len(params))
+	required := make([]string, 0, len(params))
+	for _, p := range params {
+		properties[p.Name] = paramToJSONSchema(p)
+		if p.Required {
+			required = append(required, p.Name)
+		}
+	}
+
+	return map[string]any{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+}
+
+func paramToJSONSchema(p *Param) map[string]any {
+	result := map[string]any{
+		"type": string(p.Type),
+	}
+	if p.Description != "" {
+		result["description"] = p.Description
+	}
+
+	if p.Type == TypeArray && p.Items != nil {
+		result["items"] = paramToJSONSchema(p.Items)
+	}
+
+	if p.Type == TypeObject && p.Properties != nil {
+		props := make(map[string]any, len(p.Properties))
+		required := make([]string, 0, len(p.Properties))
+		for name, prop := range p.Properties {
+			props[name] = paramToJSONSchema(prop)
+			if prop.Required {
+				required = append(required, name)
+			}
+		}
+		sort.Strings(required)
+		result["properties"] = props
+		result["required"] = required
+	}
+
+	return result
+}
@@ -0,0 +1,222 @@
+package gomind
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// UpsertResult reports a fact stored by Upsert and the stored values it
+// replaced.
+type UpsertResult struct {
+	// Fact is the fact as stored, after ontology normalisation.
+	Fact RememberRequest
+	// Replaced lists the facts with the same subject and predicate that
+	// were forgotten or, for temporal upserts, ended.
+	Replaced []Fact
+}
+
+// Upsert stores a fact and forgets every other value of the same subject
+// and predicate, so "John works_at NewCo" replaces "John works_at Acme"
+// instead of adding a second employer.
+func (c *Client) Upsert(ctx context.Context, subject, predicate, object string) (*UpsertResult, error) {
+	return c.UpsertWithOptions(ctx, RememberRequest{
+		Subject:   subject,
+		Predicate: predicate,
+		Object:    object,
+	})
+}
+
+// UpsertWithOptions is like Upsert with full control over the request
+// payload. Stored values are looked up with RecallWithOptions in
+// req.Collection. The new fact is remembered before the stale ones are
+// forgotten, so a failure part way leaves both values rather than none.
+//
+// Stored values that already have a ValidTo are history and are left
+// alone. When req.ValidFrom is set, the values it replaces are not lost
+// either: they are stored again with ValidTo set to req.ValidFrom, so
+// History still shows them. Values that only start after req.ValidFrom
+// are forgotten.
+func (c *Client) UpsertWithOptions(ctx context.Context, req RememberRequest) (*UpsertResult, error) {
+	if c.ontology != nil {
+		normalized, err := c.ontology.Normalize(req)
+		if err != nil {
+			c.logger.Error("Gomind Upsert rejected", "error", err)
+			return nil, err
+		}
+		req = normalized
+	}
+	_, replaced, err := c.upsert(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &UpsertResult{Fact: req, Replaced: replaced}, nil
+}
+
+// UpsertMany stores facts like RememberMany, replacing the stored values
+// of every subject and predicate in the batch. Several facts with the
+// same subject and predicate together become its values; facts with a
+// ValidTo are stored as history and replace nothing.
+func (c *Client) UpsertMany(ctx context.Context, facts []RememberRequest, source string) ([]UpsertResult, error) {
+	return c.UpsertManyWithOptions(ctx, RememberManyRequest{
+		Facts:  facts,
+		Source: source,
+	})
+}
+
+// UpsertManyWithOptions is like UpsertMany with full control over the
+// request payload. It returns one result per fact; the replaced values
+// of a subject and predicate are reported on its first fact, whose
+// ValidFrom ends them as in UpsertWithOptions.
+func (c *Client) UpsertManyWithOptions(ctx context.Context, req RememberManyRequest) ([]UpsertResult, error) {
+	if c.ontology != nil {
+		facts, err := c.ontology.NormalizeMany(req.Facts)
+		if err != nil {
+			c.logger.Error("Gomind UpsertMany rejected", "error", err, "factCount", len(req.Facts))
+			return nil, err
+		}
+		req.Facts = facts
+	}
+	return c.upsertMany(ctx, req, func(string) bool { return true })
+}
+
+// upsert remembers a normalised fact and forgets the other values of its
+// subject and predicate.
+func (c *Client) upsert(ctx context.Context, req RememberRequest) (*RememberResponse, []Fact, error) {
+	stale, err := c.staleFacts(ctx, req.Subject, req.Predicate, []string{req.Object}, req.Collection)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := c.remember(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := c.retireFacts(ctx, stale, req.ValidFrom, req.Collection); err != nil {
+		return resp, nil, err
+	}
+
+	c.logger.Info("Gomind Upsert success",
+		"subject", req.Subject,
+		"predicate", req.Predicate,
+		"object", req.Object,
+		"replaced", len(stale),
+	)
+	return resp, stale, nil
+}
+
+// upsertMany remembers normalised facts and forgets the other values of
+// every subject and predicate for which replace returns true.
+func (c *Client) upsertMany(ctx context.Context, req RememberManyRequest, replace func(predicate string) bool) ([]UpsertResult, error) {
+	type key struct{ subject, predicate string }
+	var keys []key
+	first := make(map[key]int)
+	objects := make(map[key][]string)
+	results := make([]UpsertResult, len(req.Facts))
+	for i, f := range req.Facts {
+		results[i].Fact = f
+		// Ended values are history and replace nothing.
+		if !replace(f.Predicate) || f.ValidTo != nil {
+			continue
+		}
+		k := key{f.Subject, f.Predicate}
+		if _, ok := first[k]; !ok {
+			first[k] = i
+			keys = append(keys, k)
+		}
+		objects[k] = append(objects[k], f.Object)
+	}
+
+	for _, k := range keys {
+		collection := req.Facts[first[k]].Collection
+		if collection == nil {
+			collection = req.Collection
+		}
+		stale, err := c.staleFacts(ctx, k.subject, k.predicate, objects[k], collection)
+		if err != nil {
+			return nil, err
+		}
+		results[first[k]].Replaced = stale
+	}
+
+	if err := c.rememberMany(ctx, req); err != nil {
+		return nil, err
+	}
+
+	replaced := 0
+	for _, k := range keys {
+		r := &results[first[k]]
+		collection := r.Fact.Collection
+		if collection == nil {
+			collection = req.Collection
+		}
+		if err := c.retireFacts(ctx, r.Replaced, r.Fact.ValidFrom, collection); err != nil {
+			return results, err
+		}
+		replaced += len(r.Replaced)
+	}
+
+	if len(keys) > 0 {
+		c.logger.Info("Gomind UpsertMany success", "factCount", len(req.Facts), "replaced", replaced)
+	}
+	return results, nil
+}
+
+// staleFacts returns the stored facts of subject and predicate whose
+// object is not in keep and that have not ended yet. Subjects must match
+// exactly: "john" is a different entity from "John" and its values are
+// left alone.
+func (c *Client) staleFacts(ctx context.Context, subject, predicate string, keep []string, collection *string) ([]Fact, error) {
+	stored, err := c.storedFacts(ctx, subject, predicate, collection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recall existing values: %w", err)
+	}
+
+	var stale []Fact
+	for _, f := range stored {
+		if object := getObjectValue(f); object != "" && f.ValidTo == nil && !slices.Contains(keep, object) {
+			stale = append(stale, f)
+		}
+	}
+	return stale, nil
+}
+
+// retireFacts forgets replaced facts. When until is set, facts that
+// started before it are stored again ending at until. The API cannot
+// update a fact in place, and remembering the ended copy first would let
+// the forget remove it too, so a failure in between loses that value.
+//
+// Facts are forgotten by ID so ended copies of the same value survive;
+// facts without an ID (servers that do not return one) are forgotten by
+// subject, predicate and object, which removes those copies as well.
+func (c *Client) retireFacts(ctx context.Context, facts []Fact, until *time.Time, collection *string) error {
+	for _, f := range facts {
+		err := c.ForgetWithOptions(ctx, ForgetRequest{
+			ID:         f.ID,
+			Subject:    f.Subject,
+			Predicate:  f.Predicate,
+			Object:     getObjectValue(f),
+			Collection: collection,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to forget replaced value %q: %w", getObjectValue(f), err)
+		}
+		if until == nil || (f.ValidFrom != nil && !f.ValidFrom.Before(*until)) {
+			continue
+		}
+		_, err = c.remember(ctx, RememberRequest{
+			Subject:    f.Subject,
+			Predicate:  f.Predicate,
+			Object:     getObjectValue(f),
+			Context:    f.Context,
+			ValidFrom:  f.ValidFrom,
+			ValidTo:    until,
+			ObservedAt: f.ObservedAt,
+			Collection: collection,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to end replaced value %q: %w", getObjectValue(f), err)
+		}
+	}
+	return nil
+}
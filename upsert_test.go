@@ -0,0 +1,283 @@
+package gomind
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+// factStore is a fake memory service keeping facts in a slice. Every
+// fact gets an ID, and forget honours ForgetRequest.ID.
+type factStore struct {
+	facts  []Fact
+	calls  []string
+	nextID int
+}
+
+func (s *factStore) add(f Fact) {
+	if f.ID == "" {
+		s.nextID++
+		f.ID = fmt.Sprintf("f%d", s.nextID)
+	}
+	s.facts = append(s.facts, f)
+}
+
+func newFactStore(t *testing.T, facts ...Fact) (*factStore, *Client) {
+	t.Helper()
+	s := &factStore{}
+	for _, f := range facts {
+		s.add(f)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.calls = append(s.calls, r.URL.Path)
+		var result any = map[string]any{}
+		switch r.URL.Path {
+		case "/v1/recall":
+			var req RecallRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			var found []Fact
+			for _, f := range s.facts {
+				if f.Predicate == req.Predicate {
+					found = append(found, f)
+				}
+			}
+			result = RecallResponse{Facts: found, Count: len(found)}
+		case "/v1/remember":
+			var req RememberRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			s.add(Fact{
+				Subject: req.Subject, Predicate: req.Predicate, Object: req.Object,
+				ValidFrom: req.ValidFrom, ValidTo: req.ValidTo, ObservedAt: req.ObservedAt,
+			})
+		case "/v1/remember_many":
+			var req RememberManyRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			for _, f := range req.Facts {
+				s.add(Fact{
+					Subject: f.Subject, Predicate: f.Predicate, Object: f.Object,
+					ValidFrom: f.ValidFrom, ValidTo: f.ValidTo, ObservedAt: f.ObservedAt,
+				})
+			}
+		case "/v1/forget":
+			var req ForgetRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			s.facts = slices.DeleteFunc(s.facts, func(f Fact) bool {
+				if req.ID != "" {
+					return f.ID == req.ID
+				}
+				return f.Subject == req.Subject && f.Predicate == req.Predicate && getObjectValue(f) == req.Object
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"status": "ok", "result": result})
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := NewClient("test-key", WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return s, client
+}
+
+func (s *factStore) objects(subject, predicate string) []string {
+	var objects []string
+	for _, f := range s.facts {
+		if f.Subject == subject && f.Predicate == predicate {
+			objects = append(objects, getObjectValue(f))
+		}
+	}
+	return objects
+}
+
+func TestUpsert(t *testing.T) {
+	store, client := newFactStore(t,
+		Fact{Subject: "John", Predicate: "works_at", Object: "Acme"},
+		Fact{Subject: "Johnny", Predicate: "works_at", Object: "Globex"},
+		Fact{Subject: "john", Predicate: "works_at", Object: "Initech"},
+	)
+
+	result, err := client.Upsert(context.Background(), "John", "works_at", "NewCo")
+	if err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	if len(result.Replaced) != 1 || result.Replaced[0].Object != "Acme" {
+		t.Errorf("unexpected replaced %+v", result.Replaced)
+	}
+	if got := store.objects("John", "works_at"); !slices.Equal(got, []string{"NewCo"}) {
+		t.Errorf("John works_at %v, want [NewCo]", got)
+	}
+	if got := store.objects("Johnny", "works_at"); !slices.Equal(got, []string{"Globex"}) {
+		t.Errorf("similar subject was touched: %v", got)
+	}
+	if got := store.objects("john", "works_at"); !slices.Equal(got, []string{"Initech"}) {
+		t.Errorf("subject differing only in case was touched: %v", got)
+	}
+
+	// Upserting the current value replaces nothing.
+	result, err = client.Upsert(context.Background(), "John", "works_at", "NewCo")
+	if err != nil || len(result.Replaced) != 0 {
+		t.Errorf("expected no replacement, got %+v, %v", result, err)
+	}
+}
+
+func TestUpsertKeepsHistory(t *testing.T) {
+	_, client := newFactStore(t,
+		Fact{Subject: "John", Predicate: "works_at", Object: "Acme", ValidFrom: date(2012, 1), ValidTo: date(2015, 1)},
+		Fact{Subject: "John", Predicate: "works_at", Object: "Initech", ValidFrom: date(2019, 1), ValidTo: date(2022, 3)},
+		Fact{Subject: "John", Predicate: "works_at", Object: "Acme", ValidFrom: date(2022, 3)},
+	)
+	ctx := context.Background()
+
+	result, err := client.UpsertWithOptions(ctx, RememberRequest{
+		Subject: "John", Predicate: "works_at", Object: "NewCo", ValidFrom: date(2025, 1),
+	})
+	if err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	// Initech had already ended and is not a current value.
+	if len(result.Replaced) != 1 || result.Replaced[0].Object != "Acme" {
+		t.Errorf("unexpected replaced %+v", result.Replaced)
+	}
+
+	history, err := client.History(ctx, "John", "works_at")
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+	// The earlier Acme period is an ended copy of the replaced triple and
+	// must survive.
+	if len(history) != 4 {
+		t.Fatalf("expected 4 values in history, got %+v", history)
+	}
+	for i, want := range []string{"Acme", "Initech", "Acme", "NewCo"} {
+		if history[i].Object != want {
+			t.Errorf("history[%d] = %s, want %s", i, history[i].Object, want)
+		}
+	}
+	if acme := history[2]; acme.ValidTo == nil || !acme.ValidTo.Equal(*date(2025, 1)) || !acme.ValidFrom.Equal(*date(2022, 3)) {
+		t.Errorf("replaced value was not ended at the new value's start: %+v", acme)
+	}
+
+	// Without a ValidFrom the replaced value is forgotten, history is kept.
+	if _, err := client.Upsert(ctx, "John", "works_at", "Globex"); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	history, _ = client.History(ctx, "John", "works_at")
+	var objects []string
+	for _, f := range history {
+		objects = append(objects, f.Object)
+	}
+	if !slices.Equal(objects, []string{"Globex", "Acme", "Initech", "Acme"}) {
+		t.Errorf("unexpected history after plain upsert %v", objects)
+	}
+}
+
+func TestUpsertMany(t *testing.T) {
+	store, client := newFactStore(t,
+		Fact{Subject: "John", Predicate: "skill", Object: "Go"},
+		Fact{Subject: "John", Predicate: "skill", Object: "Perl"},
+		Fact{Subject: "John", Predicate: "city", Object: "Paris"},
+	)
+
+	results, err := client.UpsertMany(context.Background(), []RememberRequest{
+		{Subject: "John", Predicate: "skill", Object: "Go"},
+		{Subject: "John", Predicate: "skill", Object: "Rust"},
+		{Subject: "John", Predicate: "city", Object: "Berlin"},
+	}, "test")
+	if err != nil {
+		t.Fatalf("UpsertMany: %v", err)
+	}
+	if len(results) != 3 || len(results[0].Replaced) != 1 || results[0].Replaced[0].Object != "Perl" ||
+		len(results[1].Replaced) != 0 || len(results[2].Replaced) != 1 {
+		t.Errorf("unexpected results %+v", results)
+	}
+	if got := store.objects("John", "skill"); !slices.Contains(got, "Rust") || slices.Contains(got, "Perl") {
+		t.Errorf("John skill %v", got)
+	}
+	if got := store.objects("John", "city"); !slices.Equal(got, []string{"Berlin"}) {
+		t.Errorf("John city %v, want [Berlin]", got)
+	}
+}
+
+func TestOntologyUpsertSingle(t *testing.T) {
+	store, client := newFactStore(t,
+		Fact{Subject: "John", Predicate: "works_at", Object: "Acme"},
+		Fact{Subject: "John", Predicate: "skill", Object: "Go"},
+	)
+	o, err := NewOntology(
+		PredicateDef{Name: "works_at", Cardinality: Single},
+		PredicateDef{Name: "skill"},
+	)
+	if err != nil {
+		t.Fatalf("NewOntology: %v", err)
+	}
+	o.UpsertSingle = true
+	client.ontology = o
+	ctx := context.Background()
+
+	if _, err := client.Remember(ctx, "John", "worksAt", "NewCo", ""); err != nil {
+		t.Fatalf("Remember: %v", err)
+	}
+	if err := client.RememberMany(ctx, []RememberRequest{{Subject: "John", Predicate: "skill", Object: "Rust"}}, ""); err != nil {
+		t.Fatalf("RememberMany: %v", err)
+	}
+	if got := store.objects("John", "works_at"); !slices.Equal(got, []string{"NewCo"}) {
+		t.Errorf("John works_at %v, want [NewCo]", got)
+	}
+	if got := store.objects("John", "skill"); !slices.Equal(got, []string{"Go", "Rust"}) {
+		t.Errorf("multi-valued predicate was replaced: %v", got)
+	}
+	// Remembering a past employer keeps the current one.
+	if err := client.RememberMany(ctx, []RememberRequest{
+		{Subject: "John", Predicate: "works_at", Object: "Initech", ValidFrom: date(2019, 1), ValidTo: date(2022, 3)},
+	}, ""); err != nil {
+		t.Fatalf("RememberMany: %v", err)
+	}
+	if got := store.objects("John", "works_at"); !slices.Equal(got, []string{"NewCo", "Initech"}) {
+		t.Errorf("ended value replaced the current one: %v", got)
+	}
+	// Only the single-valued predicate is looked up.
+	if n := slices.Index(store.calls, "/v1/remember_many"); slices.Contains(store.calls[n:], "/v1/recall") {
+		t.Errorf("unexpected recall for multi-valued predicate: %v", store.calls)
+	}
+}
+
+func TestUpsertSingleRespectsToolPolicy(t *testing.T) {
+	o, err := NewOntology(PredicateDef{Name: "works_at", Cardinality: Single})
+	if err != nil {
+		t.Fatalf("NewOntology: %v", err)
+	}
+	o.UpsertSingle = true
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		name   string
+		policy ToolPolicy
+		want   []string
+	}{
+		{"append only keeps stored values", AppendOnlyPolicy(), []string{"Acme", "NewCo", "Globex"}},
+		{"forget outside the collection keeps stored values",
+			ToolPolicy{ToolCollections: map[string][]string{"forget": {"scratch"}}}, []string{"Acme", "NewCo", "Globex"}},
+		{"full policy replaces", FullPolicy(), []string{"Globex"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			store, client := newFactStore(t, Fact{Subject: "John", Predicate: "works_at", Object: "Acme"})
+			client.ontology = o
+			client.toolPolicy = tt.policy
+
+			if _, err := client.HandleToolCall(ctx, "remember", `{"subject":"John","predicate":"works_at","object":"NewCo"}`); err != nil {
+				t.Fatalf("remember: %v", err)
+			}
+			if _, err := client.HandleToolCall(ctx, "remember_many", `{"facts":[{"subject":"John","predicate":"works_at","object":"Globex"}]}`); err != nil {
+				t.Fatalf("remember_many: %v", err)
+			}
+			if got := store.objects("John", "works_at"); !slices.Equal(got, tt.want) {
+				t.Errorf("John works_at %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,334 @@
+package gomind
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+)
+
+// ErrBudgetExceeded is returned, wrapped in a *BudgetExceededError, when
+// a mind request is rejected because a UsageBudget is exhausted. The
+// request is never sent.
+var ErrBudgetExceeded = errors.New("mind budget exceeded")
+
+// Default UsageOptions values.
+const (
+	DefaultUsageWindow = time.Hour
+	DefaultUsageRetain = 24
+)
+
+// UsageOptions configures a UsageAccountant.
+type UsageOptions struct {
+	// Window is the width of a time window. Usage is aggregated per
+	// window and budgets apply to the current one. Defaults to
+	// DefaultUsageWindow.
+	Window time.Duration
+
+	// Retain is the number of windows kept for snapshots, including the
+	// current one. Defaults to DefaultUsageRetain.
+	Retain int
+
+	// Budget sets optional hard limits.
+	Budget UsageBudget
+}
+
+// UsageBudget limits the tokens mind requests may use within one time
+// window. Zero or missing limits are unlimited.
+//
+// Budgets are checked against usage already recorded, since the cost of
+// a request is only known once it completes. Concurrent requests may
+// therefore overshoot a limit by their own consumption.
+type UsageBudget struct {
+	// MaxTokens limits all requests together.
+	MaxTokens int
+
+	// Collections limits requests per collection code. Use "" for the
+	// default bucket.
+	Collections map[string]int
+
+	// Tags limits requests per usage tag (see WithUsageTag).
+	Tags map[string]int
+}
+
+// BudgetExceededError reports which budget rejected a mind request. It
+// unwraps to ErrBudgetExceeded.
+type BudgetExceededError struct {
+	// Scope is "total", "collection" or "tag".
+	Scope string
+	// Key is the collection code or tag; empty for the total budget.
+	Key string
+	// Limit and Used are token counts for the current window.
+	Limit int
+	Used  int
+	// WindowEnd is when the current window closes and the budget resets.
+	WindowEnd time.Time
+}
+
+func (e *BudgetExceededError) Error() string {
+	if e.Scope == "total" {
+		return fmt.Sprintf("%s: %d of %d tokens used, resets at %s",
+			ErrBudgetExceeded, e.Used, e.Limit, e.WindowEnd.Format(time.RFC3339))
+	}
+	return fmt.Sprintf("%s for %s %q: %d of %d tokens used, resets at %s",
+		ErrBudgetExceeded, e.Scope, e.Key, e.Used, e.Limit, e.WindowEnd.Format(time.RFC3339))
+}
+
+func (e *BudgetExceededError) Unwrap() error {
+	return ErrBudgetExceeded
+}
+
+// UsageStats aggregates mind requests.
+type UsageStats struct {
+	// Requests counts completed requests.
+	Requests int
+	// Failures counts requests that returned an error.
+	Failures int
+	// Rejected counts requests refused by a budget.
+	Rejected int
+	// TokensUsed and LatencyMs are summed from MindMeta.
+	TokensUsed int
+	LatencyMs  int64
+}
+
+// AvgLatency returns the mean latency of completed requests.
+func (s UsageStats) AvgLatency() time.Duration {
+	if s.Requests == 0 {
+		return 0
+	}
+	return time.Duration(s.LatencyMs/int64(s.Requests)) * time.Millisecond
+}
+
+func (s *UsageStats) add(o UsageStats) {
+	s.Requests += o.Requests
+	s.Failures += o.Failures
+	s.Rejected += o.Rejected
+	s.TokensUsed += o.TokensUsed
+	s.LatencyMs += o.LatencyMs
+}
+
+// UsageBreakdown splits usage by collection and by tag. The default
+// bucket is keyed "" in ByCollection and untagged requests are keyed ""
+// in ByTag.
+type UsageBreakdown struct {
+	Total        UsageStats
+	ByCollection map[string]UsageStats
+	ByTag        map[string]UsageStats
+}
+
+func newUsageBreakdown() UsageBreakdown {
+	return UsageBreakdown{
+		ByCollection: make(map[string]UsageStats),
+		ByTag:        make(map[string]UsageStats),
+	}
+}
+
+func (b *UsageBreakdown) add(collection, tag string, s UsageStats) {
+	b.Total.add(s)
+	col := b.ByCollection[collection]
+	col.add(s)
+	b.ByCollection[collection] = col
+	t := b.ByTag[tag]
+	t.add(s)
+	b.ByTag[tag] = t
+}
+
+func (b UsageBreakdown) clone() UsageBreakdown {
+	return UsageBreakdown{
+		Total:        b.Total,
+		ByCollection: maps.Clone(b.ByCollection),
+		ByTag:        maps.Clone(b.ByTag),
+	}
+}
+
+// UsageWindow is the usage within one time window.
+type UsageWindow struct {
+	Start time.Time
+	End   time.Time
+	UsageBreakdown
+}
+
+// UsageSnapshot is a point-in-time copy of a UsageAccountant.
+type UsageSnapshot struct {
+	// Since is when the accountant was created or last reset.
+	Since time.Time
+	// UsageBreakdown covers everything since then.
+	UsageBreakdown
+	// Windows lists the retained windows, oldest first. The last one is
+	// the current window.
+	Windows []UsageWindow
+}
+
+// UsageAccountant aggregates the tokens and latency of mind requests per
+// collection, per tag and per time window, and enforces optional
+// budgets. Install it with WithUsageAccountant; one accountant may be
+// shared by several clients. It is safe for concurrent use.
+type UsageAccountant struct {
+	opts UsageOptions
+	now  func() time.Time
+
+	mu      sync.Mutex
+	since   time.Time
+	total   UsageBreakdown
+	windows []UsageWindow
+}
+
+// NewUsageAccountant creates an accountant.
+func NewUsageAccountant(opts UsageOptions) *UsageAccountant {
+	if opts.Window <= 0 {
+		opts.Window = DefaultUsageWindow
+	}
+	if opts.Retain <= 0 {
+		opts.Retain = DefaultUsageRetain
+	}
+	a := &UsageAccountant{opts: opts, now: time.Now}
+	a.Reset()
+	return a
+}
+
+// Snapshot returns a copy of the usage recorded so far.
+func (a *UsageAccountant) Snapshot() UsageSnapshot {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.current()
+	snap := UsageSnapshot{
+		Since:          a.since,
+		UsageBreakdown: a.total.clone(),
+		Windows:        make([]UsageWindow, len(a.windows)),
+	}
+	for i, w := range a.windows {
+		snap.Windows[i] = UsageWindow{Start: w.Start, End: w.End, UsageBreakdown: w.clone()}
+	}
+	return snap
+}
+
+// Reset discards all recorded usage.
+func (a *UsageAccountant) Reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.since = a.now()
+	a.total = newUsageBreakdown()
+	a.windows = nil
+}
+
+// check returns a *BudgetExceededError when a request for collection and
+// tag would exceed a budget in the current window, and counts the
+// rejection.
+func (a *UsageAccountant) check(collection, tag string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	w := a.current()
+	budget := a.opts.Budget
+	var err *BudgetExceededError
+	switch {
+	case budget.MaxTokens > 0 && w.Total.TokensUsed >= budget.MaxTokens:
+		err = &BudgetExceededError{Scope: "total", Limit: budget.MaxTokens, Used: w.Total.TokensUsed}
+	case overBudget(budget.Collections, collection, w.ByCollection):
+		err = &BudgetExceededError{Scope: "collection", Key: collection,
+			Limit: budget.Collections[collection], Used: w.ByCollection[collection].TokensUsed}
+	case overBudget(budget.Tags, tag, w.ByTag):
+		err = &BudgetExceededError{Scope: "tag", Key: tag,
+			Limit: budget.Tags[tag], Used: w.ByTag[tag].TokensUsed}
+	default:
+		return nil
+	}
+	err.WindowEnd = w.End
+
+	rejected := UsageStats{Rejected: 1}
+	w.add(collection, tag, rejected)
+	a.total.add(collection, tag, rejected)
+	return err
+}
+
+func overBudget(limits map[string]int, key string, used map[string]UsageStats) bool {
+	limit, ok := limits[key]
+	return ok && limit > 0 && used[key].TokensUsed >= limit
+}
+
+// record adds the outcome of a mind request.
+func (a *UsageAccountant) record(collection, tag string, meta MindMeta, failed bool) {
+	s := UsageStats{Requests: 1, TokensUsed: meta.TokensUsed, LatencyMs: int64(meta.LatencyMs)}
+	if failed {
+		s = UsageStats{Failures: 1}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.current().add(collection, tag, s)
+	a.total.add(collection, tag, s)
+}
+
+// current returns the window containing now, opening it and dropping
+// expired windows as needed. The caller must hold a.mu.
+func (a *UsageAccountant) current() *UsageWindow {
+	now := a.now()
+	if n := len(a.windows); n > 0 && now.Before(a.windows[n-1].End) {
+		return &a.windows[n-1]
+	}
+
+	start := now.Truncate(a.opts.Window)
+	a.windows = append(a.windows, UsageWindow{
+		Start:          start,
+		End:            start.Add(a.opts.Window),
+		UsageBreakdown: newUsageBreakdown(),
+	})
+	if extra := len(a.windows) - a.opts.Retain; extra > 0 {
+		a.windows = append(a.windows[:0], a.windows[extra:]...)
+	}
+	return &a.windows[len(a.windows)-1]
+}
+
+type usageTagKey struct{}
+
+// WithUsageTag returns a context whose mind requests are accounted under
+// tag, e.g. the internal team or feature making the request.
+func WithUsageTag(ctx context.Context, tag string) context.Context {
+	return context.WithValue(ctx, usageTagKey{}, tag)
+}
+
+// UsageTag returns the tag set by WithUsageTag, or "".
+func UsageTag(ctx context.Context) string {
+	tag, _ := ctx.Value(usageTagKey{}).(string)
+	return tag
+}
+
+// usageKey returns the accounting keys for a mind request whose
+// collection has already been resolved.
+func usageKey(ctx context.Context, collection *string) (string, string) {
+	code := ""
+	if collection != nil {
+		code = canonicalCollection(*collection)
+	}
+	return code, UsageTag(ctx)
+}
+
+// checkUsage enforces the client's budgets for a mind request.
+func (c *Client) checkUsage(ctx context.Context, collection *string) error {
+	if c.usage == nil {
+		return nil
+	}
+	code, tag := usageKey(ctx, collection)
+	return c.usage.check(code, tag)
+}
+
+// recordUsage accounts a finished mind request. meta is ignored when
+// failed is true.
+func (c *Client) recordUsage(ctx context.Context, collection *string, meta MindMeta, failed bool) {
+	if c.usage == nil {
+		return
+	}
+	code, tag := usageKey(ctx, collection)
+	c.usage.record(code, tag, meta, failed)
+}
+
+// Usage returns the accountant installed with WithUsageAccountant, or
+// nil.
+func (c *Client) Usage() *UsageAccountant {
+	return c.usage
+}
@@ -0,0 +1,146 @@
+package gomind
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newMindServer(t *testing.T, tokens int, calls *atomic.Int32) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"ok","result":{"result":{},"meta":{"tokens_used":` +
+			strconv.Itoa(tokens) + `,"latency_ms":40}}}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func TestUsageAccounting(t *testing.T) {
+	var calls atomic.Int32
+	url := newMindServer(t, 100, &calls)
+
+	now := time.Date(2026, 1, 1, 10, 15, 0, 0, time.UTC)
+	acct := NewUsageAccountant(UsageOptions{Window: time.Hour})
+	acct.now = func() time.Time { return now }
+	acct.Reset()
+
+	client, err := NewClient("test-key", WithBaseURL(url), WithCollection("crm"), WithUsageAccountant(acct))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	ctx := WithUsageTag(context.Background(), "sales")
+	if _, err := client.Mind(ctx, "x", nil, nil); err != nil {
+		t.Fatalf("Mind: %v", err)
+	}
+	if _, err := client.MindWithOptions(context.Background(), MindRequest{Prompt: "x", Collection: DefaultBucket()}); err != nil {
+		t.Fatalf("Mind: %v", err)
+	}
+	now = now.Add(time.Hour)
+	if _, err := client.Mind(ctx, "x", nil, nil); err != nil {
+		t.Fatalf("Mind: %v", err)
+	}
+
+	snap := client.Usage().Snapshot()
+	if snap.Total.Requests != 3 || snap.Total.TokensUsed != 300 || snap.Total.AvgLatency() != 40*time.Millisecond {
+		t.Errorf("unexpected total %+v", snap.Total)
+	}
+	if snap.ByCollection["crm"].TokensUsed != 200 || snap.ByCollection[""].TokensUsed != 100 {
+		t.Errorf("unexpected per-collection usage %+v", snap.ByCollection)
+	}
+	if snap.ByTag["sales"].Requests != 2 || snap.ByTag[""].Requests != 1 {
+		t.Errorf("unexpected per-tag usage %+v", snap.ByTag)
+	}
+	if len(snap.Windows) != 2 || snap.Windows[0].Total.Requests != 2 || snap.Windows[1].Total.Requests != 1 {
+		t.Fatalf("unexpected windows %+v", snap.Windows)
+	}
+	if !snap.Windows[1].Start.Equal(time.Date(2026, 1, 1, 11, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected window start %v", snap.Windows[1].Start)
+	}
+}
+
+func TestUsageBudget(t *testing.T) {
+	tests := []struct {
+		name   string
+		budget UsageBudget
+		ctx    context.Context
+		scope  string
+	}{
+		{"total", UsageBudget{MaxTokens: 150}, context.Background(), "total"},
+		{"collection", UsageBudget{Collections: map[string]int{"crm": 100}}, context.Background(), "collection"},
+		{"tag", UsageBudget{Tags: map[string]int{"sales": 50}}, WithUsageTag(context.Background(), "sales"), "tag"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls atomic.Int32
+			url := newMindServer(t, 100, &calls)
+			acct := NewUsageAccountant(UsageOptions{Budget: tc.budget})
+			client, err := NewClient("test-key", WithBaseURL(url), WithCollection("crm"), WithUsageAccountant(acct))
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+
+			var lastErr error
+			for range 3 {
+				if _, err := client.Mind(tc.ctx, "x", nil, nil); err != nil {
+					lastErr = err
+					break
+				}
+			}
+
+			var budgetErr *BudgetExceededError
+			if !errors.Is(lastErr, ErrBudgetExceeded) || !errors.As(lastErr, &budgetErr) || budgetErr.Scope != tc.scope {
+				t.Fatalf("expected %s budget error, got %v", tc.scope, lastErr)
+			}
+			if got := calls.Load(); got != int32(budgetErr.Used/100) {
+				t.Errorf("expected no request after the budget was exhausted, server saw %d", got)
+			}
+			if acct.Snapshot().Total.Rejected != 1 {
+				t.Errorf("expected the rejection to be counted")
+			}
+		})
+	}
+}
+
+func TestUsageBudgetUnaffectedScope(t *testing.T) {
+	var calls atomic.Int32
+	url := newMindServer(t, 100, &calls)
+	acct := NewUsageAccountant(UsageOptions{Budget: UsageBudget{Collections: map[string]int{"crm": 100}}})
+	client, err := NewClient("test-key", WithBaseURL(url), WithCollection("crm"), WithUsageAccountant(acct))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	if _, err := client.Mind(context.Background(), "x", nil, nil); err != nil {
+		t.Fatalf("Mind: %v", err)
+	}
+	other := MindRequest{Prompt: "x", Collection: CollectionScope("support")}
+	if _, err := client.MindWithOptions(context.Background(), other); err != nil {
+		t.Errorf("expected other collections to stay within budget, got %v", err)
+	}
+}
+
+func TestUsageMindStream(t *testing.T) {
+	client := newSSEServer(t,
+		"event: result\ndata: {\"result\":{},\"meta\":{\"tokens_used\":12,\"latency_ms\":30}}\n\n",
+	)
+	acct := NewUsageAccountant(UsageOptions{})
+	client.usage = acct
+
+	for _, err := range client.MindStream(context.Background(), MindRequest{Prompt: "x"}) {
+		if err != nil {
+			t.Fatalf("MindStream: %v", err)
+		}
+	}
+	if s := acct.Snapshot().Total; s.Requests != 1 || s.TokensUsed != 12 {
+		t.Errorf("unexpected stream usage %+v", s)
+	}
+}